@@ -1,10 +1,20 @@
 package atom
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/internal/shared"
+)
+
+const (
+	atomNS  = "http://www.w3.org/2005/Atom"
+	xhtmlNS = "http://www.w3.org/1999/xhtml"
 )
 
 // Feed is an Atom Feed
@@ -28,6 +38,68 @@ type Feed struct {
 	Version       string         `json:"version"`
 }
 
+// Marshal the serialized Atom 1.0 xml for the feed
+func (f Feed) Marshal() ([]byte, error) {
+	output, err := xml.Marshal(f)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return []byte(xml.Header + string(output)), nil
+}
+
+// MarshalIndent the serialized Atom 1.0 xml for the feed
+func (f Feed) MarshalIndent(prefix, indent string) ([]byte, error) {
+	output, err := xml.MarshalIndent(f, prefix, indent)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return []byte(xml.Header + string(output)), nil
+}
+
+// MarshalXML is a custom xml marshaller which writes the feed
+// as an Atom 1.0 document, declaring the namespaces of any
+// extensions found in the feed, its entries and their sources.
+func (f Feed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	root := xml.StartElement{
+		Name: xml.Name{Local: "feed"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: atomNS}},
+	}
+	if f.Language != "" {
+		root.Attr = append(root.Attr, xml.Attr{Name: xml.Name{Local: "xml:lang"}, Value: f.Language})
+	}
+
+	prefixes := map[string]string{}
+	collectPrefixes(prefixes, f.Extensions)
+	for _, entry := range f.Entries {
+		collectPrefixes(prefixes, entry.Extensions)
+		if entry.Source != nil {
+			collectPrefixes(prefixes, entry.Source.Extensions)
+		}
+	}
+	root.Attr = append(root.Attr, namespaceAttrs(prefixes)...)
+
+	e.EncodeToken(root)
+
+	encode(e, "id", f.ID)
+	encodeText(e, "title", f.Title)
+	encodeDate(e, "updated", f.Updated, f.UpdatedParsed)
+	encodeText(e, "subtitle", f.Subtitle)
+	encode(e, "link", f.Links)
+	encode(e, "generator", f.Generator)
+	encode(e, "icon", f.Icon)
+	encode(e, "logo", f.Logo)
+	encodeText(e, "rights", f.Rights)
+	encode(e, "author", f.Authors)
+	encode(e, "contributor", f.Contributors)
+	encode(e, "category", f.Categories)
+	f.Extensions.Encode(e)
+	encode(e, "entry", f.Entries)
+
+	return e.EncodeToken(root.End())
+}
+
 func (f Feed) String() string {
 	json, _ := json.MarshalIndent(f, "", "    ")
 	return string(json)
@@ -52,6 +124,29 @@ type Entry struct {
 	Extensions      ext.Extensions `json:"extensions,omitempty"`
 }
 
+// MarshalXML is a custom xml marshaller which writes the
+// entry as an Atom 1.0 entry element
+func (en Entry) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "entry"}}
+	e.EncodeToken(start)
+
+	encode(e, "id", en.ID)
+	encodeText(e, "title", en.Title)
+	encodeDate(e, "updated", en.Updated, en.UpdatedParsed)
+	encodeDate(e, "published", en.Published, en.PublishedParsed)
+	encode(e, "author", en.Authors)
+	encode(e, "contributor", en.Contributors)
+	encode(e, "category", en.Categories)
+	encode(e, "link", en.Links)
+	encodeText(e, "rights", en.Rights)
+	encode(e, "source", en.Source)
+	encodeText(e, "summary", en.Summary)
+	encode(e, "content", en.Content)
+	en.Extensions.Encode(e)
+
+	return e.EncodeToken(start.End())
+}
+
 // Category is category metadata for Feeds and Entries
 type Category struct {
 	Term   string `json:"term,omitempty"`
@@ -59,6 +154,17 @@ type Category struct {
 	Label  string `json:"label,omitempty"`
 }
 
+// MarshalXML is a custom xml marshaller which writes the
+// category as an Atom 1.0 category element
+func (c Category) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "category"}}
+	start.Attr = appendAttr(start.Attr, "term", c.Term)
+	start.Attr = appendAttr(start.Attr, "scheme", c.Scheme)
+	start.Attr = appendAttr(start.Attr, "label", c.Label)
+	e.EncodeToken(start)
+	return e.EncodeToken(start.End())
+}
+
 // Person represents a person in an Atom feed
 // for things like Authors, Contributors, etc
 type Person struct {
@@ -67,6 +173,18 @@ type Person struct {
 	URI   string `json:"uri,omitempty"`
 }
 
+// MarshalXML is a custom xml marshaller which writes the person
+// as an Atom 1.0 person construct named after the start element
+// (e.g. author or contributor)
+func (p Person) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: start.Name}
+	e.EncodeToken(start)
+	encode(e, "name", p.Name)
+	encode(e, "email", p.Email)
+	encode(e, "uri", p.URI)
+	return e.EncodeToken(start.End())
+}
+
 // Link is an Atom link that defines a reference
 // from an entry or feed to a Web resource
 type Link struct {
@@ -78,6 +196,20 @@ type Link struct {
 	Length   string `json:"length,omitempty"`
 }
 
+// MarshalXML is a custom xml marshaller which writes the
+// link as an Atom 1.0 link element
+func (l Link) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "link"}}
+	start.Attr = appendAttr(start.Attr, "href", l.Href)
+	start.Attr = appendAttr(start.Attr, "rel", l.Rel)
+	start.Attr = appendAttr(start.Attr, "type", l.Type)
+	start.Attr = appendAttr(start.Attr, "hreflang", l.Hreflang)
+	start.Attr = appendAttr(start.Attr, "title", l.Title)
+	start.Attr = appendAttr(start.Attr, "length", l.Length)
+	e.EncodeToken(start)
+	return e.EncodeToken(start.End())
+}
+
 // Content either contains or links to the content of
 // the entry
 type Content struct {
@@ -86,6 +218,33 @@ type Content struct {
 	Value string `json:"value,omitempty"`
 }
 
+// MarshalXML is a custom xml marshaller which writes the content
+// as an Atom 1.0 content element.  Text, html and xhtml content is
+// written inline, content with a src attribute is written as out of
+// line content and any other non-xml media type is base64 encoded.
+func (c Content) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "content"}}
+	start.Attr = appendAttr(start.Attr, "type", c.Type)
+	start.Attr = appendAttr(start.Attr, "src", c.Src)
+
+	lowerType := strings.ToLower(c.Type)
+	switch {
+	case c.Src != "":
+		e.EncodeToken(start)
+	case lowerType == "xhtml":
+		return encodeXHTML(e, start, c.Value)
+	case lowerType == "" || lowerType == "text" || lowerType == "html" ||
+		strings.HasPrefix(lowerType, "text/") || isXMLType(lowerType):
+		e.EncodeToken(start)
+		e.EncodeToken(xml.CharData(c.Value))
+	default:
+		e.EncodeToken(start)
+		e.EncodeToken(xml.CharData(base64.StdEncoding.EncodeToString([]byte(c.Value))))
+	}
+
+	return e.EncodeToken(start.End())
+}
+
 // Generator identifies the agent used to generate a
 // feed, for debugging and other purposes.
 type Generator struct {
@@ -94,6 +253,17 @@ type Generator struct {
 	Version string `json:"version,omitempty"`
 }
 
+// MarshalXML is a custom xml marshaller which writes the
+// generator as an Atom 1.0 generator element
+func (g Generator) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "generator"}}
+	start.Attr = appendAttr(start.Attr, "uri", g.URI)
+	start.Attr = appendAttr(start.Attr, "version", g.Version)
+	e.EncodeToken(start)
+	e.EncodeToken(xml.CharData(g.Value))
+	return e.EncodeToken(start.End())
+}
+
 // Source contains the feed information for another
 // feed if a given entry came from that feed.
 type Source struct {
@@ -112,3 +282,122 @@ type Source struct {
 	Categories    []*Category    `json:"categories,omitempty"`
 	Extensions    ext.Extensions `json:"extensions,omitempty"`
 }
+
+// MarshalXML is a custom xml marshaller which writes the
+// source as an Atom 1.0 source element
+func (s Source) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "source"}}
+	e.EncodeToken(start)
+
+	encode(e, "id", s.ID)
+	encodeText(e, "title", s.Title)
+	encodeDate(e, "updated", s.Updated, s.UpdatedParsed)
+	encodeText(e, "subtitle", s.Subtitle)
+	encode(e, "link", s.Links)
+	encode(e, "generator", s.Generator)
+	encode(e, "icon", s.Icon)
+	encode(e, "logo", s.Logo)
+	encodeText(e, "rights", s.Rights)
+	encode(e, "author", s.Authors)
+	encode(e, "contributor", s.Contributors)
+	encode(e, "category", s.Categories)
+	s.Extensions.Encode(e)
+
+	return e.EncodeToken(start.End())
+}
+
+func encode(e *xml.Encoder, name string, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+
+	if sval, ok := val.(string); ok && len(sval) == 0 {
+		return nil
+	}
+
+	return e.EncodeElement(val, xml.StartElement{Name: xml.Name{Local: name}})
+}
+
+// encodeText writes an Atom text construct, marking it as
+// html if the value contains markup
+func encodeText(e *xml.Encoder, name, val string) error {
+	if val == "" {
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if strings.Contains(val, "<") {
+		start.Attr = appendAttr(start.Attr, "type", "html")
+	}
+
+	e.EncodeToken(start)
+	e.EncodeToken(xml.CharData(val))
+	return e.EncodeToken(start.End())
+}
+
+// encodeDate writes an Atom date construct, preferring the
+// parsed date so that the output is always RFC 3339
+func encodeDate(e *xml.Encoder, name, val string, parsed *time.Time) error {
+	if parsed != nil {
+		val = parsed.Format(time.RFC3339)
+	}
+	return encode(e, name, val)
+}
+
+func encodeXHTML(e *xml.Encoder, start xml.StartElement, val string) error {
+	div := struct {
+		XMLName xml.Name `xml:"div"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Inner   string   `xml:",innerxml"`
+	}{Xmlns: xhtmlNS, Inner: val}
+
+	e.EncodeToken(start)
+	e.Encode(div)
+	return e.EncodeToken(start.End())
+}
+
+func isXMLType(mediaType string) bool {
+	return strings.HasSuffix(mediaType, "+xml") || strings.HasSuffix(mediaType, "/xml")
+}
+
+func appendAttr(attrs []xml.Attr, name, val string) []xml.Attr {
+	if val == "" {
+		return attrs
+	}
+	return append(attrs, xml.Attr{Name: xml.Name{Local: name}, Value: val})
+}
+
+// collectPrefixes records the prefixes used by the extensions,
+// along with the namespace recorded on their elements, which is
+// used for prefixes that are not canonical.
+func collectPrefixes(prefixes map[string]string, extensions ext.Extensions) {
+	for prefix := range extensions {
+		if _, ok := prefixes[prefix]; !ok {
+			prefixes[prefix] = ""
+		}
+	}
+	for prefix, space := range extensions.Namespaces() {
+		if prefixes[prefix] == "" {
+			prefixes[prefix] = space
+		}
+	}
+}
+
+func namespaceAttrs(prefixes map[string]string) (attrs []xml.Attr) {
+	names := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		names = append(names, prefix)
+	}
+	sort.Strings(names)
+
+	for _, prefix := range names {
+		space := shared.NamespaceForPrefix(prefix)
+		if space == "" {
+			space = prefixes[prefix]
+		}
+		if space != "" {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: space})
+		}
+	}
+	return
+}
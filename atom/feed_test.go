@@ -0,0 +1,87 @@
+package atom_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed_Marshal_RoundTrip(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/parser/atom/atom10_*.xml")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		fmt.Printf("Testing %s... ", name)
+
+		data, _ := ioutil.ReadFile(f)
+
+		fp := &atom.Parser{}
+		expected, err := fp.Parse(bytes.NewReader(data))
+		require.Nil(t, err)
+
+		output, err := expected.MarshalIndent("", "  ")
+		require.Nil(t, err)
+
+		fp = &atom.Parser{}
+		actual, err := fp.Parse(bytes.NewReader(output))
+		require.Nil(t, err, "Remarshalled feed file %s.xml could not be parsed", name)
+
+		if assert.Equal(t, expected, actual, "Remarshalled feed file %s.xml did not match original", name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestFeed_Marshal(t *testing.T) {
+	feedData := `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/" xml:lang="en">
+<title>Feed &amp; Title</title>
+<updated>2020-01-01T00:00:00Z</updated>
+<generator uri="http://example.com" version="1.0">Generator</generator>
+<entry>
+<id>urn:entry:1</id>
+<title type="html">&lt;b&gt;Bold&lt;/b&gt;</title>
+<link rel="enclosure" href="http://example.com/a.mp3" length="100" type="audio/mpeg"/>
+<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Hello</p></div></content>
+<media:thumbnail url="http://example.com/thumb.jpg"/>
+</entry>
+<entry>
+<content type="image/png">aGVsbG8=</content>
+</entry>
+<entry>
+<content type="text/html" src="http://example.com/post"/>
+</entry>
+</feed>`
+
+	fp := &atom.Parser{}
+	feed, err := fp.Parse(strings.NewReader(feedData))
+	require.Nil(t, err)
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	xml := string(output)
+
+	assert.Contains(t, xml, `<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en" xmlns:media="http://search.yahoo.com/mrss/">`)
+	assert.Contains(t, xml, `<title>Feed &amp; Title</title>`)
+	assert.Contains(t, xml, `<generator uri="http://example.com" version="1.0">Generator</generator>`)
+	assert.Contains(t, xml, `<title type="html">&lt;b&gt;Bold&lt;/b&gt;</title>`)
+	assert.Contains(t, xml, `<link href="http://example.com/a.mp3" rel="enclosure" type="audio/mpeg" length="100"></link>`)
+	assert.Contains(t, xml, `<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Hello</p></div></content>`)
+	assert.Contains(t, xml, `<media:thumbnail url="http://example.com/thumb.jpg"></media:thumbnail>`)
+	assert.Contains(t, xml, `<content type="image/png">aGVsbG8=</content>`)
+	assert.Contains(t, xml, `<content type="text/html" src="http://example.com/post"></content>`)
+
+	fp = &atom.Parser{}
+	actual, err := fp.Parse(bytes.NewReader(output))
+	require.Nil(t, err)
+	assert.Equal(t, feed, actual)
+}
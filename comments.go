@@ -0,0 +1,59 @@
+package gofeed
+
+import (
+	"strconv"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// InReplyTo identifies the resource that a given Item is a
+// reply to, as described by the Atom Threading Extension
+// (RFC 4685).
+type InReplyTo struct {
+	Ref    string `json:"ref,omitempty"`
+	Href   string `json:"href,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// parseCommentsFeed returns the comment feed link of the
+// wfw extension, accepting the commentRSS spelling used by
+// some publishers.
+func parseCommentsFeed(extensions ext.Extensions) string {
+	wfw := extensions["wfw"]
+	for _, name := range []string{"commentRss", "commentRSS"} {
+		if matches, ok := wfw[name]; ok && len(matches) > 0 {
+			return strings.TrimSpace(matches[0].Value)
+		}
+	}
+	return ""
+}
+
+// parseCommentCount returns the number of comments given by
+// slash:comments or thr:total.  Counts which are not
+// non-negative integers are ignored.
+func parseCommentCount(extensions ext.Extensions) *int {
+	for _, e := range []struct{ prefix, name string }{{"slash", "comments"}, {"thr", "total"}} {
+		matches, ok := extensions[e.prefix][e.name]
+		if !ok || len(matches) == 0 {
+			continue
+		}
+		if count, err := strconv.Atoi(strings.TrimSpace(matches[0].Value)); err == nil && count >= 0 {
+			return &count
+		}
+	}
+	return nil
+}
+
+func parseInReplyTo(extensions ext.Extensions) (replies []*InReplyTo) {
+	for _, r := range extensions["thr"]["in-reply-to"] {
+		replies = append(replies, &InReplyTo{
+			Ref:    r.Attrs["ref"],
+			Href:   r.Attrs["href"],
+			Type:   r.Attrs["type"],
+			Source: r.Attrs["source"],
+		})
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package gofeed_test
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_Comments_RSS(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<rss version="2.0"
+  xmlns:wfw="http://wellformedweb.org/commentAPI/"
+  xmlns:slash="http://purl.org/rss/1.0/modules/slash/"
+  xmlns:thr="http://purl.org/rss/1.0/modules/threading/">
+<channel>
+<item>
+<title>Post</title>
+<comments>https://example.com/post#comments</comments>
+<wfw:commentRss>https://example.com/post/feed/</wfw:commentRss>
+<slash:comments>12</slash:comments>
+</item>
+<item>
+<title>Reply</title>
+<wfw:commentRSS>https://example.com/reply/feed/</wfw:commentRSS>
+<slash:comments>many</slash:comments>
+</item>
+</channel>
+</rss>`)
+	require.Nil(t, err)
+	require.Len(t, feed.Items, 2)
+
+	post := feed.Items[0]
+	assert.Equal(t, "https://example.com/post#comments", post.CommentsLink)
+	assert.Equal(t, "https://example.com/post/feed/", post.CommentsFeed)
+	require.NotNil(t, post.CommentCount)
+	assert.Equal(t, 12, *post.CommentCount)
+
+	reply := feed.Items[1]
+	assert.Equal(t, "", reply.CommentsLink)
+	assert.Equal(t, "https://example.com/reply/feed/", reply.CommentsFeed)
+	assert.Nil(t, reply.CommentCount)
+}
+
+func TestItem_Comments_Atom(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<feed xmlns="http://www.w3.org/2005/Atom"
+  xmlns:t="http://purl.org/syndication/thread/1.0">
+<title>Blog</title>
+<entry>
+<id>tag:example.com,2021:reply</id>
+<title>Reply</title>
+<link rel="replies" type="application/atom+xml" href="https://example.com/reply/comments.atom"/>
+<link rel="replies" type="text/html" href="https://example.com/reply#comments"/>
+<t:in-reply-to ref="tag:example.com,2021:post" href="https://example.com/post" type="text/html"/>
+<t:total>0</t:total>
+</entry>
+</feed>`)
+	require.Nil(t, err)
+	require.Len(t, feed.Items, 1)
+
+	item := feed.Items[0]
+	assert.Equal(t, "https://example.com/reply#comments", item.CommentsLink)
+	assert.Equal(t, "https://example.com/reply/comments.atom", item.CommentsFeed)
+	require.NotNil(t, item.CommentCount)
+	assert.Equal(t, 0, *item.CommentCount)
+	assert.Equal(t, []*gofeed.InReplyTo{{
+		Ref:  "tag:example.com,2021:post",
+		Href: "https://example.com/post",
+		Type: "text/html",
+	}}, item.InReplyTo)
+}
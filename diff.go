@@ -0,0 +1,91 @@
+package gofeed
+
+import "time"
+
+// FeedDiff describes the changes between two fetches
+// of the same feed.
+type FeedDiff struct {
+	New     []*Item `json:"new,omitempty"`
+	Updated []*Item `json:"updated,omitempty"`
+	Removed []*Item `json:"removed,omitempty"`
+}
+
+// HasChanges returns true if any items were added,
+// updated or removed.
+func (d *FeedDiff) HasChanges() bool {
+	return len(d.New) > 0 || len(d.Updated) > 0 || len(d.Removed) > 0
+}
+
+// Diff compares a previously fetched feed with a freshly
+// parsed version of the same feed and reports the items that
+// are new, updated or removed.
+//
+// Items are matched by their Identity.  If the feed's GUIDs are
+// regenerated between fetches (see UnstableGUIDs) items are
+// matched by their FallbackIdentity instead.  An item is considered
+// updated if its title, description, content or dates changed.
+// New and Updated contain items from curr, Removed contains
+// items from prev.  Either feed may be nil.
+func Diff(prev, curr *Feed) *FeedDiff {
+	diff := &FeedDiff{}
+	key := (*Item).Identity
+	if UnstableGUIDs(prev, curr) {
+		key = (*Item).FallbackIdentity
+	}
+
+	prevItems := map[string]*Item{}
+	if prev != nil {
+		for _, item := range prev.Items {
+			id := key(item)
+			if _, ok := prevItems[id]; !ok {
+				prevItems[id] = item
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	if curr != nil {
+		for _, item := range curr.Items {
+			id := key(item)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			old, ok := prevItems[id]
+			if !ok {
+				diff.New = append(diff.New, item)
+			} else if itemChanged(old, item) {
+				diff.Updated = append(diff.Updated, item)
+			}
+		}
+	}
+
+	if prev != nil {
+		for _, item := range prev.Items {
+			id := key(item)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			diff.Removed = append(diff.Removed, item)
+		}
+	}
+
+	return diff
+}
+
+func itemChanged(prev, curr *Item) bool {
+	return prev.Title != curr.Title ||
+		prev.Description != curr.Description ||
+		prev.Content != curr.Content ||
+		!timeEqual(prev.UpdatedParsed, curr.UpdatedParsed) ||
+		!timeEqual(prev.PublishedParsed, curr.PublishedParsed)
+}
+
+func timeEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
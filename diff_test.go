@@ -0,0 +1,66 @@
+package gofeed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	published := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	republished := published.Add(time.Hour)
+
+	prev := &gofeed.Feed{
+		Items: []*gofeed.Item{
+			{GUID: "1", Title: "One", PublishedParsed: &published},
+			{GUID: "2", Title: "Two"},
+			{Link: "http://example.com/3", Title: "Three"},
+			{Title: "Four", Content: "four"},
+			{GUID: "5", Title: "Five"},
+		},
+	}
+
+	curr := &gofeed.Feed{
+		Items: []*gofeed.Item{
+			{GUID: "1", Title: "One", PublishedParsed: &republished},
+			{GUID: "2", Title: "Two"},
+			{Link: "http://example.com/3", Title: "Three (edited)"},
+			{Title: "Four", Content: "four"},
+			{GUID: "6", Title: "Six"},
+			{Title: "Seven", Content: "seven"},
+		},
+	}
+
+	diff := gofeed.Diff(prev, curr)
+	assert.True(t, diff.HasChanges())
+
+	assert.Len(t, diff.New, 2)
+	assert.Equal(t, "Six", diff.New[0].Title)
+	assert.Equal(t, "Seven", diff.New[1].Title)
+
+	assert.Len(t, diff.Updated, 2)
+	assert.Equal(t, "One", diff.Updated[0].Title)
+	assert.Equal(t, "Three (edited)", diff.Updated[1].Title)
+
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "Five", diff.Removed[0].Title)
+}
+
+func TestDiff_NilFeeds(t *testing.T) {
+	curr := &gofeed.Feed{
+		Items: []*gofeed.Item{{GUID: "1"}},
+	}
+
+	diff := gofeed.Diff(nil, curr)
+	assert.Len(t, diff.New, 1)
+	assert.Empty(t, diff.Removed)
+
+	diff = gofeed.Diff(curr, nil)
+	assert.Empty(t, diff.New)
+	assert.Len(t, diff.Removed, 1)
+
+	diff = gofeed.Diff(curr, curr)
+	assert.False(t, diff.HasChanges())
+}
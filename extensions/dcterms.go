@@ -0,0 +1,207 @@
+package ext
+
+import "encoding/xml"
+
+// DublinCoreTermsExtension represents a feed extension
+// for the DCMI Metadata Terms (http://purl.org/dc/terms/).
+// Terms which refer to another resource, such as license or
+// isPartOf, hold the URI of the rdf:resource attribute when
+// the element has no text.
+type DublinCoreTermsExtension struct {
+	Abstract              []string `json:"abstract,omitempty"`
+	AccessRights          []string `json:"accessRights,omitempty"`
+	AccrualMethod         []string `json:"accrualMethod,omitempty"`
+	AccrualPeriodicity    []string `json:"accrualPeriodicity,omitempty"`
+	AccrualPolicy         []string `json:"accrualPolicy,omitempty"`
+	Alternative           []string `json:"alternative,omitempty"`
+	Audience              []string `json:"audience,omitempty"`
+	Available             []string `json:"available,omitempty"`
+	BibliographicCitation []string `json:"bibliographicCitation,omitempty"`
+	ConformsTo            []string `json:"conformsTo,omitempty"`
+	Contributor           []string `json:"contributor,omitempty"`
+	Coverage              []string `json:"coverage,omitempty"`
+	Created               []string `json:"created,omitempty"`
+	Creator               []string `json:"creator,omitempty"`
+	Date                  []string `json:"date,omitempty"`
+	DateAccepted          []string `json:"dateAccepted,omitempty"`
+	DateCopyrighted       []string `json:"dateCopyrighted,omitempty"`
+	DateSubmitted         []string `json:"dateSubmitted,omitempty"`
+	Description           []string `json:"description,omitempty"`
+	EducationLevel        []string `json:"educationLevel,omitempty"`
+	Extent                []string `json:"extent,omitempty"`
+	Format                []string `json:"format,omitempty"`
+	HasFormat             []string `json:"hasFormat,omitempty"`
+	HasPart               []string `json:"hasPart,omitempty"`
+	HasVersion            []string `json:"hasVersion,omitempty"`
+	Identifier            []string `json:"identifier,omitempty"`
+	InstructionalMethod   []string `json:"instructionalMethod,omitempty"`
+	IsFormatOf            []string `json:"isFormatOf,omitempty"`
+	IsPartOf              []string `json:"isPartOf,omitempty"`
+	IsReferencedBy        []string `json:"isReferencedBy,omitempty"`
+	IsReplacedBy          []string `json:"isReplacedBy,omitempty"`
+	IsRequiredBy          []string `json:"isRequiredBy,omitempty"`
+	Issued                []string `json:"issued,omitempty"`
+	IsVersionOf           []string `json:"isVersionOf,omitempty"`
+	Language              []string `json:"language,omitempty"`
+	License               []string `json:"license,omitempty"`
+	Mediator              []string `json:"mediator,omitempty"`
+	Medium                []string `json:"medium,omitempty"`
+	Modified              []string `json:"modified,omitempty"`
+	Provenance            []string `json:"provenance,omitempty"`
+	Publisher             []string `json:"publisher,omitempty"`
+	References            []string `json:"references,omitempty"`
+	Relation              []string `json:"relation,omitempty"`
+	Replaces              []string `json:"replaces,omitempty"`
+	Requires              []string `json:"requires,omitempty"`
+	Rights                []string `json:"rights,omitempty"`
+	RightsHolder          []string `json:"rightsHolder,omitempty"`
+	Source                []string `json:"source,omitempty"`
+	Spatial               []string `json:"spatial,omitempty"`
+	Subject               []string `json:"subject,omitempty"`
+	TableOfContents       []string `json:"tableOfContents,omitempty"`
+	Temporal              []string `json:"temporal,omitempty"`
+	Title                 []string `json:"title,omitempty"`
+	Type                  []string `json:"type,omitempty"`
+	Valid                 []string `json:"valid,omitempty"`
+}
+
+// Encode will encode the dublin core terms extension in the provided xml encoder
+func (dcterms DublinCoreTermsExtension) Encode(e *xml.Encoder) error {
+	encodeStringArray(e, "dcterms:abstract", dcterms.Abstract)
+	encodeStringArray(e, "dcterms:accessRights", dcterms.AccessRights)
+	encodeStringArray(e, "dcterms:accrualMethod", dcterms.AccrualMethod)
+	encodeStringArray(e, "dcterms:accrualPeriodicity", dcterms.AccrualPeriodicity)
+	encodeStringArray(e, "dcterms:accrualPolicy", dcterms.AccrualPolicy)
+	encodeStringArray(e, "dcterms:alternative", dcterms.Alternative)
+	encodeStringArray(e, "dcterms:audience", dcterms.Audience)
+	encodeStringArray(e, "dcterms:available", dcterms.Available)
+	encodeStringArray(e, "dcterms:bibliographicCitation", dcterms.BibliographicCitation)
+	encodeStringArray(e, "dcterms:conformsTo", dcterms.ConformsTo)
+	encodeStringArray(e, "dcterms:contributor", dcterms.Contributor)
+	encodeStringArray(e, "dcterms:coverage", dcterms.Coverage)
+	encodeStringArray(e, "dcterms:created", dcterms.Created)
+	encodeStringArray(e, "dcterms:creator", dcterms.Creator)
+	encodeStringArray(e, "dcterms:date", dcterms.Date)
+	encodeStringArray(e, "dcterms:dateAccepted", dcterms.DateAccepted)
+	encodeStringArray(e, "dcterms:dateCopyrighted", dcterms.DateCopyrighted)
+	encodeStringArray(e, "dcterms:dateSubmitted", dcterms.DateSubmitted)
+	encodeStringArray(e, "dcterms:description", dcterms.Description)
+	encodeStringArray(e, "dcterms:educationLevel", dcterms.EducationLevel)
+	encodeStringArray(e, "dcterms:extent", dcterms.Extent)
+	encodeStringArray(e, "dcterms:format", dcterms.Format)
+	encodeStringArray(e, "dcterms:hasFormat", dcterms.HasFormat)
+	encodeStringArray(e, "dcterms:hasPart", dcterms.HasPart)
+	encodeStringArray(e, "dcterms:hasVersion", dcterms.HasVersion)
+	encodeStringArray(e, "dcterms:identifier", dcterms.Identifier)
+	encodeStringArray(e, "dcterms:instructionalMethod", dcterms.InstructionalMethod)
+	encodeStringArray(e, "dcterms:isFormatOf", dcterms.IsFormatOf)
+	encodeStringArray(e, "dcterms:isPartOf", dcterms.IsPartOf)
+	encodeStringArray(e, "dcterms:isReferencedBy", dcterms.IsReferencedBy)
+	encodeStringArray(e, "dcterms:isReplacedBy", dcterms.IsReplacedBy)
+	encodeStringArray(e, "dcterms:isRequiredBy", dcterms.IsRequiredBy)
+	encodeStringArray(e, "dcterms:issued", dcterms.Issued)
+	encodeStringArray(e, "dcterms:isVersionOf", dcterms.IsVersionOf)
+	encodeStringArray(e, "dcterms:language", dcterms.Language)
+	encodeStringArray(e, "dcterms:license", dcterms.License)
+	encodeStringArray(e, "dcterms:mediator", dcterms.Mediator)
+	encodeStringArray(e, "dcterms:medium", dcterms.Medium)
+	encodeStringArray(e, "dcterms:modified", dcterms.Modified)
+	encodeStringArray(e, "dcterms:provenance", dcterms.Provenance)
+	encodeStringArray(e, "dcterms:publisher", dcterms.Publisher)
+	encodeStringArray(e, "dcterms:references", dcterms.References)
+	encodeStringArray(e, "dcterms:relation", dcterms.Relation)
+	encodeStringArray(e, "dcterms:replaces", dcterms.Replaces)
+	encodeStringArray(e, "dcterms:requires", dcterms.Requires)
+	encodeStringArray(e, "dcterms:rights", dcterms.Rights)
+	encodeStringArray(e, "dcterms:rightsHolder", dcterms.RightsHolder)
+	encodeStringArray(e, "dcterms:source", dcterms.Source)
+	encodeStringArray(e, "dcterms:spatial", dcterms.Spatial)
+	encodeStringArray(e, "dcterms:subject", dcterms.Subject)
+	encodeStringArray(e, "dcterms:tableOfContents", dcterms.TableOfContents)
+	encodeStringArray(e, "dcterms:temporal", dcterms.Temporal)
+	encodeStringArray(e, "dcterms:title", dcterms.Title)
+	encodeStringArray(e, "dcterms:type", dcterms.Type)
+	encodeStringArray(e, "dcterms:valid", dcterms.Valid)
+	return nil
+}
+
+// NewDublinCoreTermsExtension creates a new DublinCoreTermsExtension
+// given the generic extension map for the "dcterms" prefix.
+func NewDublinCoreTermsExtension(extensions map[string][]Extension) *DublinCoreTermsExtension {
+	dcterms := &DublinCoreTermsExtension{}
+	dcterms.Abstract = parseResourceArrayExtension("abstract", extensions)
+	dcterms.AccessRights = parseResourceArrayExtension("accessRights", extensions)
+	dcterms.AccrualMethod = parseResourceArrayExtension("accrualMethod", extensions)
+	dcterms.AccrualPeriodicity = parseResourceArrayExtension("accrualPeriodicity", extensions)
+	dcterms.AccrualPolicy = parseResourceArrayExtension("accrualPolicy", extensions)
+	dcterms.Alternative = parseResourceArrayExtension("alternative", extensions)
+	dcterms.Audience = parseResourceArrayExtension("audience", extensions)
+	dcterms.Available = parseResourceArrayExtension("available", extensions)
+	dcterms.BibliographicCitation = parseResourceArrayExtension("bibliographicCitation", extensions)
+	dcterms.ConformsTo = parseResourceArrayExtension("conformsTo", extensions)
+	dcterms.Contributor = parseResourceArrayExtension("contributor", extensions)
+	dcterms.Coverage = parseResourceArrayExtension("coverage", extensions)
+	dcterms.Created = parseResourceArrayExtension("created", extensions)
+	dcterms.Creator = parseResourceArrayExtension("creator", extensions)
+	dcterms.Date = parseResourceArrayExtension("date", extensions)
+	dcterms.DateAccepted = parseResourceArrayExtension("dateAccepted", extensions)
+	dcterms.DateCopyrighted = parseResourceArrayExtension("dateCopyrighted", extensions)
+	dcterms.DateSubmitted = parseResourceArrayExtension("dateSubmitted", extensions)
+	dcterms.Description = parseResourceArrayExtension("description", extensions)
+	dcterms.EducationLevel = parseResourceArrayExtension("educationLevel", extensions)
+	dcterms.Extent = parseResourceArrayExtension("extent", extensions)
+	dcterms.Format = parseResourceArrayExtension("format", extensions)
+	dcterms.HasFormat = parseResourceArrayExtension("hasFormat", extensions)
+	dcterms.HasPart = parseResourceArrayExtension("hasPart", extensions)
+	dcterms.HasVersion = parseResourceArrayExtension("hasVersion", extensions)
+	dcterms.Identifier = parseResourceArrayExtension("identifier", extensions)
+	dcterms.InstructionalMethod = parseResourceArrayExtension("instructionalMethod", extensions)
+	dcterms.IsFormatOf = parseResourceArrayExtension("isFormatOf", extensions)
+	dcterms.IsPartOf = parseResourceArrayExtension("isPartOf", extensions)
+	dcterms.IsReferencedBy = parseResourceArrayExtension("isReferencedBy", extensions)
+	dcterms.IsReplacedBy = parseResourceArrayExtension("isReplacedBy", extensions)
+	dcterms.IsRequiredBy = parseResourceArrayExtension("isRequiredBy", extensions)
+	dcterms.Issued = parseResourceArrayExtension("issued", extensions)
+	dcterms.IsVersionOf = parseResourceArrayExtension("isVersionOf", extensions)
+	dcterms.Language = parseResourceArrayExtension("language", extensions)
+	dcterms.License = parseResourceArrayExtension("license", extensions)
+	dcterms.Mediator = parseResourceArrayExtension("mediator", extensions)
+	dcterms.Medium = parseResourceArrayExtension("medium", extensions)
+	dcterms.Modified = parseResourceArrayExtension("modified", extensions)
+	dcterms.Provenance = parseResourceArrayExtension("provenance", extensions)
+	dcterms.Publisher = parseResourceArrayExtension("publisher", extensions)
+	dcterms.References = parseResourceArrayExtension("references", extensions)
+	dcterms.Relation = parseResourceArrayExtension("relation", extensions)
+	dcterms.Replaces = parseResourceArrayExtension("replaces", extensions)
+	dcterms.Requires = parseResourceArrayExtension("requires", extensions)
+	dcterms.Rights = parseResourceArrayExtension("rights", extensions)
+	dcterms.RightsHolder = parseResourceArrayExtension("rightsHolder", extensions)
+	dcterms.Source = parseResourceArrayExtension("source", extensions)
+	dcterms.Spatial = parseResourceArrayExtension("spatial", extensions)
+	dcterms.Subject = parseResourceArrayExtension("subject", extensions)
+	dcterms.TableOfContents = parseResourceArrayExtension("tableOfContents", extensions)
+	dcterms.Temporal = parseResourceArrayExtension("temporal", extensions)
+	dcterms.Title = parseResourceArrayExtension("title", extensions)
+	dcterms.Type = parseResourceArrayExtension("type", extensions)
+	dcterms.Valid = parseResourceArrayExtension("valid", extensions)
+	return dcterms
+}
+
+// parseResourceArrayExtension returns the text of each matching
+// element, or its rdf:resource attribute if it has no text.
+func parseResourceArrayExtension(name string, extensions map[string][]Extension) (values []string) {
+	matches, ok := extensions[name]
+	if !ok || len(matches) == 0 {
+		return
+	}
+
+	values = []string{}
+	for _, m := range matches {
+		if m.Value == "" && m.Attrs["resource"] != "" {
+			values = append(values, m.Attrs["resource"])
+		} else {
+			values = append(values, m.Value)
+		}
+	}
+	return
+}
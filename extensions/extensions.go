@@ -1,6 +1,9 @@
 package ext
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"sort"
+)
 
 // Extensions is the generic extension map for Feeds and Items.
 // The first map is for the element namespace prefix (e.g., itunes).
@@ -9,11 +12,237 @@ type Extensions map[string]map[string][]Extension
 
 // Extension represents a single XML element that was in a non
 // default namespace in a Feed or Item/Entry.
+//
+// Attrs and Children are keyed by local name, and Value holds the
+// trimmed text of the element.  The parsers also record the
+// namespace of the element, its attributes with their namespaces
+// in document order, and its text and children in document order,
+// which allows the element to be encoded again as it was written.
 type Extension struct {
-	Name     string                 `json:"name"`
-	Value    string                 `json:"value"`
-	Attrs    map[string]string      `json:"attrs"`
-	Children map[string][]Extension `json:"children"`
+	Name       string                 `json:"name"`
+	Namespace  string                 `json:"namespace,omitempty"`
+	Prefix     string                 `json:"prefix,omitempty"`
+	Value      string                 `json:"value"`
+	Attrs      map[string]string      `json:"attrs"`
+	Attributes []Attr                 `json:"attributes,omitempty"`
+	Children   map[string][]Extension `json:"children"`
+	Content    []Node                 `json:"content,omitempty"`
+
+	// JSONType is the type of the JSON Feed value the element
+	// was parsed from when it is a "number", "boolean", "null",
+	// "object" or "array" (an array nested in an array), so that
+	// it can be written back unchanged.  It is empty for strings
+	// and elements parsed from XML.
+	JSONType string `json:"jsonType,omitempty"`
+
+	// JSONArray is set on the elements parsed from the values of
+	// a JSON array, so that an array of one value is written
+	// back as an array.
+	JSONArray bool `json:"jsonArray,omitempty"`
+
+	// JSONRoot is set on the elements parsed from a JSON Feed
+	// extension which is not an object, such as "_rating":
+	// "adult", and which are stored under the name of their
+	// prefix.
+	JSONRoot bool `json:"jsonRoot,omitempty"`
+}
+
+// Attr is an attribute of an extension element.  Namespace is
+// empty for attributes without a prefix.
+type Attr struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Value     string `json:"value"`
+}
+
+// Node is an item of the mixed content of an extension element.
+// It is either a run of text, or a reference to the child element
+// at Children[Child][Index].
+type Node struct {
+	Text  string `json:"text,omitempty"`
+	Child string `json:"child,omitempty"`
+	Index int    `json:"index,omitempty"`
+}
+
+// Namespace returns the extension elements of the given namespace
+// URI, keyed by element name, whatever prefix the feed bound the
+// namespace to.  It returns nil if there are none.
+func (e Extensions) Namespace(uri string) map[string][]Extension {
+	for _, prefix := range sortedKeys(e) {
+		for _, elements := range e[prefix] {
+			if len(elements) > 0 && elements[0].Namespace == uri {
+				return e[prefix]
+			}
+		}
+	}
+	return nil
+}
+
+// Namespaces returns the namespace URIs of the extension elements
+// and their attributes, keyed by the prefix they are encoded with.
+func (e Extensions) Namespaces() map[string]string {
+	spaces := map[string]string{}
+	for prefix, elements := range e {
+		for _, matches := range elements {
+			for _, x := range matches {
+				x.namespaces(prefix, spaces)
+			}
+		}
+	}
+	return spaces
+}
+
+func (e Extension) namespaces(prefix string, spaces map[string]string) {
+	if e.Namespace != "" {
+		if _, ok := spaces[prefix]; !ok {
+			spaces[prefix] = e.Namespace
+		}
+	}
+	for _, attr := range e.Attributes {
+		if attr.Prefix != "" && attr.Prefix != "xml" && attr.Namespace != "" {
+			if _, ok := spaces[attr.Prefix]; !ok {
+				spaces[attr.Prefix] = attr.Namespace
+			}
+		}
+	}
+	for _, children := range e.Children {
+		for _, child := range children {
+			child.namespaces(child.prefix(prefix), spaces)
+		}
+	}
+}
+
+// AttrNS returns the value of the attribute with the given
+// namespace URI and local name.  An empty namespace matches
+// attributes without a prefix.
+func (e Extension) AttrNS(namespace, name string) (string, bool) {
+	for _, attr := range e.Attributes {
+		if attr.Namespace == namespace && attr.Name == name {
+			return attr.Value, true
+		}
+	}
+	if namespace == "" && len(e.Attributes) == 0 {
+		value, ok := e.Attrs[name]
+		return value, ok
+	}
+	return "", false
+}
+
+// Encode will encode every extension element in the provided
+// xml encoder, using the map keys as namespace prefixes.
+func (e Extensions) Encode(enc *xml.Encoder) error {
+	for _, prefix := range sortedKeys(e) {
+		elements := e[prefix]
+		names := make([]string, 0, len(elements))
+		for name := range elements {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			for _, x := range elements[name] {
+				if err := x.Encode(enc, prefix); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Encode will encode the extension element and its children
+// in the provided xml encoder under the given namespace prefix.
+// When the element has Attributes or Content they are encoded in
+// their recorded order, otherwise Attrs and Children are encoded
+// sorted by name.  Children which were parsed in a different
+// namespace keep their own prefix.
+func (e Extension) Encode(enc *xml.Encoder, prefix string) error {
+	start := xml.StartElement{Name: xml.Name{Local: qualifiedName(prefix, e.Name)}}
+	if len(e.Attributes) > 0 {
+		for _, attr := range e.Attributes {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: qualifiedName(attr.Prefix, attr.Name)}, Value: attr.Value})
+		}
+	} else {
+		attrs := make([]string, 0, len(e.Attrs))
+		for k := range e.Attrs {
+			attrs = append(attrs, k)
+		}
+		sort.Strings(attrs)
+		for _, k := range attrs {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: k}, Value: e.Attrs[k]})
+		}
+	}
+
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if len(e.Content) > 0 {
+		for _, node := range e.Content {
+			if node.Child == "" {
+				if err := enc.EncodeToken(xml.CharData(node.Text)); err != nil {
+					return err
+				}
+				continue
+			}
+			children := e.Children[node.Child]
+			if node.Index < 0 || node.Index >= len(children) {
+				continue
+			}
+			child := children[node.Index]
+			if err := child.Encode(enc, child.prefix(prefix)); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(xml.EndElement{Name: start.Name})
+	}
+
+	if e.Value != "" {
+		if err := enc.EncodeToken(xml.CharData(e.Value)); err != nil {
+			return err
+		}
+	}
+
+	children := make([]string, 0, len(e.Children))
+	for k := range e.Children {
+		children = append(children, k)
+	}
+	sort.Strings(children)
+	for _, k := range children {
+		for _, child := range e.Children[k] {
+			if err := child.Encode(enc, child.prefix(prefix)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return enc.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// prefix returns the prefix to encode a child element with,
+// given the prefix of its parent.
+func (e Extension) prefix(parent string) string {
+	if e.Prefix != "" {
+		return e.Prefix
+	}
+	return parent
+}
+
+func qualifiedName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + ":" + name
+}
+
+func sortedKeys(e Extensions) []string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func parseTextExtension(name string, extensions map[string][]Extension) (value string) {
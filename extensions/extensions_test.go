@@ -0,0 +1,111 @@
+package ext_test
+
+import (
+	"strings"
+	"testing"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const extensionsFeed = `<rss version="2.0" xmlns:x="http://example.com/ns/x" xmlns:y="http://example.com/ns/y">
+<channel>
+<title>Title</title>
+<x:note y:id="2" id="1" xml:lang="en">Some <x:b>bold</x:b> and <y:i>other</y:i> text</x:note>
+<x:list>
+  <x:entry>b</x:entry>
+  <x:other>c</x:other>
+  <x:entry>a</x:entry>
+</x:list>
+</channel>
+</rss>`
+
+func parseExtensionsFeed(t *testing.T, data string) *rss.Feed {
+	feed, err := (&rss.Parser{}).Parse(strings.NewReader(data))
+	require.Nil(t, err)
+	return feed
+}
+
+func TestExtension_Namespaces(t *testing.T) {
+	feed := parseExtensionsFeed(t, extensionsFeed)
+
+	note := feed.Extensions["x"]["note"][0]
+	assert.Equal(t, "http://example.com/ns/x", note.Namespace)
+	assert.Equal(t, "x", note.Prefix)
+	assert.Equal(t, "http://example.com/ns/y", note.Children["i"][0].Namespace)
+	assert.Equal(t, "y", note.Children["i"][0].Prefix)
+
+	assert.Equal(t, feed.Extensions["x"], feed.Extensions.Namespace("http://example.com/ns/x"))
+	assert.Nil(t, feed.Extensions.Namespace("http://example.com/ns/z"))
+	assert.Equal(t, map[string]string{
+		"x": "http://example.com/ns/x",
+		"y": "http://example.com/ns/y",
+	}, feed.Extensions.Namespaces())
+}
+
+func TestExtension_Attributes(t *testing.T) {
+	feed := parseExtensionsFeed(t, extensionsFeed)
+	note := feed.Extensions["x"]["note"][0]
+
+	assert.Equal(t, []ext.Attr{
+		{Name: "id", Namespace: "http://example.com/ns/y", Prefix: "y", Value: "2"},
+		{Name: "id", Value: "1"},
+		{Name: "lang", Namespace: "http://www.w3.org/XML/1998/namespace", Prefix: "xml", Value: "en"},
+	}, note.Attributes)
+
+	id, ok := note.AttrNS("", "id")
+	assert.True(t, ok)
+	assert.Equal(t, "1", id)
+	id, ok = note.AttrNS("http://example.com/ns/y", "id")
+	assert.True(t, ok)
+	assert.Equal(t, "2", id)
+	_, ok = note.AttrNS("http://example.com/ns/x", "id")
+	assert.False(t, ok)
+
+	hand := ext.Extension{Name: "note", Attrs: map[string]string{"id": "3"}}
+	id, ok = hand.AttrNS("", "id")
+	assert.True(t, ok)
+	assert.Equal(t, "3", id)
+}
+
+func TestExtension_Content(t *testing.T) {
+	feed := parseExtensionsFeed(t, extensionsFeed)
+
+	note := feed.Extensions["x"]["note"][0]
+	assert.Equal(t, []ext.Node{
+		{Text: "Some "},
+		{Child: "b"},
+		{Text: " and "},
+		{Child: "i"},
+		{Text: " text"},
+	}, note.Content)
+	assert.Nil(t, note.Children["b"][0].Content)
+
+	list := feed.Extensions["x"]["list"][0]
+	assert.Equal(t, []ext.Node{
+		{Child: "entry"},
+		{Child: "other"},
+		{Child: "entry", Index: 1},
+	}, list.Content)
+}
+
+func TestExtension_Encode(t *testing.T) {
+	feed := parseExtensionsFeed(t, extensionsFeed)
+	// Declare the namespaces from the elements rather than
+	// copying the declarations of the original root element.
+	feed.RootAttrs = nil
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	xml := string(output)
+
+	assert.Contains(t, xml, `xmlns:x="http://example.com/ns/x"`)
+	assert.Contains(t, xml, `xmlns:y="http://example.com/ns/y"`)
+	assert.Contains(t, xml, `<x:note y:id="2" id="1" xml:lang="en">Some <x:b>bold</x:b> and <y:i>other</y:i> text</x:note>`)
+	assert.Contains(t, xml, `<x:list><x:entry>b</x:entry><x:other>c</x:other><x:entry>a</x:entry></x:list>`)
+
+	actual := parseExtensionsFeed(t, xml)
+	assert.Equal(t, feed.Extensions, actual.Extensions)
+}
@@ -31,16 +31,10 @@ func (itunes ITunesFeedExtension) Encode(e *xml.Encoder) error {
 	encode(e, "itunes:subtitle", itunes.Subtitle)
 	encode(e, "itunes:summary", itunes.Summary)
 
-	e.EncodeToken(xml.StartElement{
-		Name: xml.Name{Local: "itunes:image"},
-		Attr: []xml.Attr{
-			{Name: xml.Name{Local: "href"}, Value: itunes.Image},
-		},
-	})
-	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "itunes:image"}})
+	encodeImage(e, itunes.Image)
 
 	encode(e, "itunes:complete", itunes.Complete)
-	encode(e, "itunes:newFeedUrl", itunes.NewFeedURL)
+	encode(e, "itunes:new-feed-url", itunes.NewFeedURL)
 	encode(e, "itunes:type", itunes.Type)
 
 	return nil
@@ -74,13 +68,7 @@ func (itunes ITunesItemExtension) Encode(e *xml.Encoder) error {
 	encode(e, "itunes:subtitle", itunes.Subtitle)
 	encode(e, "itunes:summary", itunes.Summary)
 
-	e.EncodeToken(xml.StartElement{
-		Name: xml.Name{Local: "itunes:image"},
-		Attr: []xml.Attr{
-			{Name: xml.Name{Local: "href"}, Value: itunes.Image},
-		},
-	})
-	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "itunes:image"}})
+	encodeImage(e, itunes.Image)
 
 	encode(e, "itunes:isClosedCaptioned", itunes.IsClosedCaptioned)
 	encode(e, "itunes:episode", itunes.Episode)
@@ -208,3 +196,17 @@ func parseCategories(extensions map[string][]Extension) (categories []*ITunesCat
 	}
 	return
 }
+
+func encodeImage(e *xml.Encoder, href string) error {
+	if href == "" {
+		return nil
+	}
+
+	e.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "itunes:image"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "href"}, Value: href},
+		},
+	})
+	return e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "itunes:image"}})
+}
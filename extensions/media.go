@@ -0,0 +1,247 @@
+package ext
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// MediaExtension is a set of extension fields from the
+// Media RSS namespace (http://search.yahoo.com/mrss/) for
+// feeds and items.
+type MediaExtension struct {
+	Contents    []*MediaContent   `json:"contents,omitempty"`
+	Groups      []*MediaGroup     `json:"groups,omitempty"`
+	Title       *MediaText        `json:"title,omitempty"`
+	Description *MediaText        `json:"description,omitempty"`
+	Thumbnails  []*MediaThumbnail `json:"thumbnails,omitempty"`
+	Player      *MediaPlayer      `json:"player,omitempty"`
+	Credits     []*MediaCredit    `json:"credits,omitempty"`
+	Ratings     []*MediaRating    `json:"ratings,omitempty"`
+}
+
+// Encode will encode the media extension in the provided xml encoder
+func (media MediaExtension) Encode(e *xml.Encoder) error {
+	encode(e, "media:content", media.Contents)
+	encode(e, "media:group", media.Groups)
+	encode(e, "media:title", media.Title)
+	encode(e, "media:description", media.Description)
+	encode(e, "media:thumbnail", media.Thumbnails)
+	encode(e, "media:player", media.Player)
+	encode(e, "media:credit", media.Credits)
+	encode(e, "media:rating", media.Ratings)
+	return nil
+}
+
+// Image returns the URL of the image that best represents
+// the feed or item: the first thumbnail, looking in the
+// groups and contents when there is none at the top level,
+// or else the first media object which is an image.
+func (media MediaExtension) Image() string {
+	if len(media.Thumbnails) > 0 {
+		return media.Thumbnails[0].URL
+	}
+	for _, g := range media.Groups {
+		if len(g.Thumbnails) > 0 {
+			return g.Thumbnails[0].URL
+		}
+	}
+	contents := media.AllContents()
+	for _, c := range contents {
+		if len(c.Thumbnails) > 0 {
+			return c.Thumbnails[0].URL
+		}
+	}
+	for _, c := range contents {
+		if c.IsImage() {
+			return c.URL
+		}
+	}
+	return ""
+}
+
+// AllContents returns the media objects of the extension,
+// followed by the media objects in each of its groups.
+func (media MediaExtension) AllContents() (contents []*MediaContent) {
+	contents = append(contents, media.Contents...)
+	for _, g := range media.Groups {
+		contents = append(contents, g.Contents...)
+	}
+	return
+}
+
+// MediaGroup is a set of media objects which are different
+// representations of the same content.
+type MediaGroup struct {
+	Contents    []*MediaContent   `json:"contents,omitempty"    xml:"media:content,omitempty"`
+	Title       *MediaText        `json:"title,omitempty"       xml:"media:title,omitempty"`
+	Description *MediaText        `json:"description,omitempty" xml:"media:description,omitempty"`
+	Thumbnails  []*MediaThumbnail `json:"thumbnails,omitempty"  xml:"media:thumbnail,omitempty"`
+	Player      *MediaPlayer      `json:"player,omitempty"      xml:"media:player,omitempty"`
+	Credits     []*MediaCredit    `json:"credits,omitempty"     xml:"media:credit,omitempty"`
+	Ratings     []*MediaRating    `json:"ratings,omitempty"     xml:"media:rating,omitempty"`
+}
+
+// MediaContent is a single media object, such as an
+// image, audio or video file.
+type MediaContent struct {
+	URL          string            `json:"url,omitempty"          xml:"url,attr,omitempty"`
+	FileSize     string            `json:"fileSize,omitempty"     xml:"fileSize,attr,omitempty"`
+	Type         string            `json:"type,omitempty"         xml:"type,attr,omitempty"`
+	Medium       string            `json:"medium,omitempty"       xml:"medium,attr,omitempty"`
+	IsDefault    string            `json:"isDefault,omitempty"    xml:"isDefault,attr,omitempty"`
+	Expression   string            `json:"expression,omitempty"   xml:"expression,attr,omitempty"`
+	Bitrate      string            `json:"bitrate,omitempty"      xml:"bitrate,attr,omitempty"`
+	Framerate    string            `json:"framerate,omitempty"    xml:"framerate,attr,omitempty"`
+	SamplingRate string            `json:"samplingrate,omitempty" xml:"samplingrate,attr,omitempty"`
+	Channels     string            `json:"channels,omitempty"     xml:"channels,attr,omitempty"`
+	Duration     string            `json:"duration,omitempty"     xml:"duration,attr,omitempty"`
+	Height       string            `json:"height,omitempty"       xml:"height,attr,omitempty"`
+	Width        string            `json:"width,omitempty"        xml:"width,attr,omitempty"`
+	Lang         string            `json:"lang,omitempty"         xml:"lang,attr,omitempty"`
+	Title        *MediaText        `json:"title,omitempty"        xml:"media:title,omitempty"`
+	Description  *MediaText        `json:"description,omitempty"  xml:"media:description,omitempty"`
+	Thumbnails   []*MediaThumbnail `json:"thumbnails,omitempty"   xml:"media:thumbnail,omitempty"`
+	Player       *MediaPlayer      `json:"player,omitempty"       xml:"media:player,omitempty"`
+	Credits      []*MediaCredit    `json:"credits,omitempty"      xml:"media:credit,omitempty"`
+	Ratings      []*MediaRating    `json:"ratings,omitempty"      xml:"media:rating,omitempty"`
+}
+
+// IsImage returns true if the media object is an image,
+// going by its medium or else its MIME type.
+func (c MediaContent) IsImage() bool {
+	if c.Medium != "" {
+		return c.Medium == "image"
+	}
+	return strings.HasPrefix(c.Type, "image/")
+}
+
+// MediaText is a title or description, where Type is
+// either plain or html.
+type MediaText struct {
+	Type  string `json:"type,omitempty"  xml:"type,attr,omitempty"`
+	Value string `json:"value,omitempty" xml:",chardata"`
+}
+
+// MediaThumbnail is an image which represents the media object.
+type MediaThumbnail struct {
+	URL    string `json:"url,omitempty"    xml:"url,attr,omitempty"`
+	Height string `json:"height,omitempty" xml:"height,attr,omitempty"`
+	Width  string `json:"width,omitempty"  xml:"width,attr,omitempty"`
+	Time   string `json:"time,omitempty"   xml:"time,attr,omitempty"`
+}
+
+// MediaPlayer is a web page which plays the media object.
+type MediaPlayer struct {
+	URL    string `json:"url,omitempty"    xml:"url,attr,omitempty"`
+	Height string `json:"height,omitempty" xml:"height,attr,omitempty"`
+	Width  string `json:"width,omitempty"  xml:"width,attr,omitempty"`
+}
+
+// MediaCredit is an entity which contributed to the media object.
+type MediaCredit struct {
+	Role   string `json:"role,omitempty"   xml:"role,attr,omitempty"`
+	Scheme string `json:"scheme,omitempty" xml:"scheme,attr,omitempty"`
+	Value  string `json:"value,omitempty"  xml:",chardata"`
+}
+
+// MediaRating is the audience the media object is suitable for.
+type MediaRating struct {
+	Scheme string `json:"scheme,omitempty" xml:"scheme,attr,omitempty"`
+	Value  string `json:"value,omitempty"  xml:",chardata"`
+}
+
+// NewMediaExtension creates a MediaExtension given an
+// extension map for the "media" key.
+func NewMediaExtension(extensions map[string][]Extension) *MediaExtension {
+	media := &MediaExtension{}
+	for _, c := range extensions["content"] {
+		media.Contents = append(media.Contents, parseMediaContent(c))
+	}
+	for _, g := range extensions["group"] {
+		group := &MediaGroup{}
+		for _, c := range g.Children["content"] {
+			group.Contents = append(group.Contents, parseMediaContent(c))
+		}
+		group.Title = parseMediaText("title", g.Children)
+		group.Description = parseMediaText("description", g.Children)
+		group.Thumbnails = parseMediaThumbnails(g.Children)
+		group.Player = parseMediaPlayer(g.Children)
+		group.Credits = parseMediaCredits(g.Children)
+		group.Ratings = parseMediaRatings(g.Children)
+		media.Groups = append(media.Groups, group)
+	}
+	media.Title = parseMediaText("title", extensions)
+	media.Description = parseMediaText("description", extensions)
+	media.Thumbnails = parseMediaThumbnails(extensions)
+	media.Player = parseMediaPlayer(extensions)
+	media.Credits = parseMediaCredits(extensions)
+	media.Ratings = parseMediaRatings(extensions)
+	return media
+}
+
+func parseMediaContent(c Extension) *MediaContent {
+	return &MediaContent{
+		URL:          c.Attrs["url"],
+		FileSize:     c.Attrs["fileSize"],
+		Type:         c.Attrs["type"],
+		Medium:       c.Attrs["medium"],
+		IsDefault:    c.Attrs["isDefault"],
+		Expression:   c.Attrs["expression"],
+		Bitrate:      c.Attrs["bitrate"],
+		Framerate:    c.Attrs["framerate"],
+		SamplingRate: c.Attrs["samplingrate"],
+		Channels:     c.Attrs["channels"],
+		Duration:     c.Attrs["duration"],
+		Height:       c.Attrs["height"],
+		Width:        c.Attrs["width"],
+		Lang:         c.Attrs["lang"],
+		Title:        parseMediaText("title", c.Children),
+		Description:  parseMediaText("description", c.Children),
+		Thumbnails:   parseMediaThumbnails(c.Children),
+		Player:       parseMediaPlayer(c.Children),
+		Credits:      parseMediaCredits(c.Children),
+		Ratings:      parseMediaRatings(c.Children),
+	}
+}
+
+func parseMediaText(name string, extensions map[string][]Extension) *MediaText {
+	text := firstExtension(name, extensions)
+	if text == nil {
+		return nil
+	}
+	return &MediaText{Type: text.Attrs["type"], Value: text.Value}
+}
+
+func parseMediaThumbnails(extensions map[string][]Extension) (thumbnails []*MediaThumbnail) {
+	for _, t := range extensions["thumbnail"] {
+		thumbnails = append(thumbnails, &MediaThumbnail{
+			URL:    t.Attrs["url"],
+			Height: t.Attrs["height"],
+			Width:  t.Attrs["width"],
+			Time:   t.Attrs["time"],
+		})
+	}
+	return
+}
+
+func parseMediaPlayer(extensions map[string][]Extension) *MediaPlayer {
+	player := firstExtension("player", extensions)
+	if player == nil {
+		return nil
+	}
+	return &MediaPlayer{URL: player.Attrs["url"], Height: player.Attrs["height"], Width: player.Attrs["width"]}
+}
+
+func parseMediaCredits(extensions map[string][]Extension) (credits []*MediaCredit) {
+	for _, c := range extensions["credit"] {
+		credits = append(credits, &MediaCredit{Role: c.Attrs["role"], Scheme: c.Attrs["scheme"], Value: c.Value})
+	}
+	return
+}
+
+func parseMediaRatings(extensions map[string][]Extension) (ratings []*MediaRating) {
+	for _, r := range extensions["rating"] {
+		ratings = append(ratings, &MediaRating{Scheme: r.Attrs["scheme"], Value: r.Value})
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package ext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMedia_Extensions(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/extensions/media/*.xml")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		fmt.Printf("Testing %s... ", name)
+
+		// Get actual source feed
+		ff := fmt.Sprintf("../testdata/extensions/media/%s.xml", name)
+		f, _ := ioutil.ReadFile(ff)
+
+		// Parse actual feed
+		fp := gofeed.NewParser()
+		actual, _ := fp.Parse(bytes.NewReader(f))
+
+		// Get json encoded expected feed result
+		ef := fmt.Sprintf("../testdata/extensions/media/%s.json", name)
+		e, _ := ioutil.ReadFile(ef)
+
+		// Unmarshal expected feed
+		expected := &gofeed.Feed{}
+		json.Unmarshal(e, &expected)
+
+		if assert.Equal(t, expected, actual, "Feed file %s.xml did not match expected output %s.json", name, name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestMedia_Image(t *testing.T) {
+	media := ext.MediaExtension{
+		Contents: []*ext.MediaContent{
+			{URL: "https://example.com/video.mp4", Type: "video/mp4"},
+			{URL: "https://example.com/photo.jpg", Medium: "image"},
+		},
+	}
+	assert.Equal(t, "https://example.com/photo.jpg", media.Image())
+
+	media.Groups = []*ext.MediaGroup{{Thumbnails: []*ext.MediaThumbnail{{URL: "https://example.com/group.jpg"}}}}
+	assert.Equal(t, "https://example.com/group.jpg", media.Image())
+
+	media.Thumbnails = []*ext.MediaThumbnail{{URL: "https://example.com/thumb.jpg"}}
+	assert.Equal(t, "https://example.com/thumb.jpg", media.Image())
+
+	assert.Equal(t, "", ext.MediaExtension{}.Image())
+}
+
+func TestMedia_Encode(t *testing.T) {
+	data, _ := ioutil.ReadFile("../testdata/extensions/media/media_flickr.xml")
+	fp := &rss.Parser{}
+	expected, err := fp.Parse(bytes.NewReader(data))
+	require.Nil(t, err)
+
+	// Only the typed extension is left to be encoded
+	feed := rss.Feed{
+		Title: "Photos",
+		Items: []*rss.Item{{Title: "Photo", MediaExt: expected.Items[0].MediaExt}},
+	}
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	assert.Contains(t, string(output), `xmlns:media="http://search.yahoo.com/mrss/"`)
+	assert.Contains(t, string(output), `<media:credit role="photographer">Example</media:credit>`)
+
+	fp = &rss.Parser{}
+	actual, err := fp.Parse(bytes.NewReader(output))
+	require.Nil(t, err)
+	assert.Equal(t, expected.Items[0].MediaExt, actual.Items[0].MediaExt)
+}
@@ -0,0 +1,301 @@
+package ext
+
+import "encoding/xml"
+
+// PodcastFeedExtension is a set of extension fields from the
+// Podcasting 2.0 namespace (https://podcastindex.org/namespace/1.0)
+// for RSS feeds.
+type PodcastFeedExtension struct {
+	Locked   *PodcastLocked    `json:"locked,omitempty"`
+	Funding  []*PodcastFunding `json:"funding,omitempty"`
+	Persons  []*PodcastPerson  `json:"persons,omitempty"`
+	Location *PodcastLocation  `json:"location,omitempty"`
+	Value    *PodcastValue     `json:"value,omitempty"`
+	GUID     string            `json:"guid,omitempty"`
+	Medium   string            `json:"medium,omitempty"`
+}
+
+// Encode will encode the podcast extension in the provided xml encoder
+func (podcast PodcastFeedExtension) Encode(e *xml.Encoder) error {
+	encode(e, "podcast:locked", podcast.Locked)
+	encode(e, "podcast:funding", podcast.Funding)
+	encode(e, "podcast:person", podcast.Persons)
+	encode(e, "podcast:location", podcast.Location)
+	encode(e, "podcast:value", podcast.Value)
+	encode(e, "podcast:guid", podcast.GUID)
+	encode(e, "podcast:medium", podcast.Medium)
+	return nil
+}
+
+// PodcastItemExtension is a set of extension fields from the
+// Podcasting 2.0 namespace for RSS items.
+type PodcastItemExtension struct {
+	Transcripts         []*PodcastTranscript         `json:"transcripts,omitempty"`
+	Chapters            *PodcastChapters             `json:"chapters,omitempty"`
+	Soundbites          []*PodcastSoundbite          `json:"soundbites,omitempty"`
+	Persons             []*PodcastPerson             `json:"persons,omitempty"`
+	Location            *PodcastLocation             `json:"location,omitempty"`
+	Season              *PodcastSeason               `json:"season,omitempty"`
+	Episode             *PodcastEpisode              `json:"episode,omitempty"`
+	Value               *PodcastValue                `json:"value,omitempty"`
+	AlternateEnclosures []*PodcastAlternateEnclosure `json:"alternateEnclosures,omitempty"`
+}
+
+// Encode will encode the podcast item in the provided xml encoder
+func (podcast PodcastItemExtension) Encode(e *xml.Encoder) error {
+	encode(e, "podcast:transcript", podcast.Transcripts)
+	encode(e, "podcast:chapters", podcast.Chapters)
+	encode(e, "podcast:soundbite", podcast.Soundbites)
+	encode(e, "podcast:person", podcast.Persons)
+	encode(e, "podcast:location", podcast.Location)
+	encode(e, "podcast:season", podcast.Season)
+	encode(e, "podcast:episode", podcast.Episode)
+	encode(e, "podcast:value", podcast.Value)
+	encode(e, "podcast:alternateEnclosure", podcast.AlternateEnclosures)
+	return nil
+}
+
+// PodcastLocked tells other platforms whether they are
+// allowed to import the feed.
+type PodcastLocked struct {
+	Owner string `json:"owner,omitempty" xml:"owner,attr,omitempty"`
+	Value string `json:"value,omitempty" xml:",chardata"`
+}
+
+// PodcastFunding is a link to donate to or support the podcast.
+type PodcastFunding struct {
+	URL   string `json:"url,omitempty"   xml:"url,attr,omitempty"`
+	Value string `json:"value,omitempty" xml:",chardata"`
+}
+
+// PodcastPerson is a person of interest to the podcast
+// or one of its episodes.
+type PodcastPerson struct {
+	Name  string `json:"name,omitempty"  xml:",chardata"`
+	Role  string `json:"role,omitempty"  xml:"role,attr,omitempty"`
+	Group string `json:"group,omitempty" xml:"group,attr,omitempty"`
+	Img   string `json:"img,omitempty"   xml:"img,attr,omitempty"`
+	Href  string `json:"href,omitempty"  xml:"href,attr,omitempty"`
+}
+
+// PodcastLocation is the location the podcast or
+// episode is about.
+type PodcastLocation struct {
+	Name string `json:"name,omitempty" xml:",chardata"`
+	Geo  string `json:"geo,omitempty"  xml:"geo,attr,omitempty"`
+	OSM  string `json:"osm,omitempty"  xml:"osm,attr,omitempty"`
+}
+
+// PodcastValue describes how listeners can send payments
+// to the podcast while listening.
+type PodcastValue struct {
+	Type       string                   `json:"type,omitempty"       xml:"type,attr,omitempty"`
+	Method     string                   `json:"method,omitempty"     xml:"method,attr,omitempty"`
+	Suggested  string                   `json:"suggested,omitempty"  xml:"suggested,attr,omitempty"`
+	Recipients []*PodcastValueRecipient `json:"recipients,omitempty" xml:"podcast:valueRecipient,omitempty"`
+}
+
+// PodcastValueRecipient is one of the recipients of a
+// value payment.
+type PodcastValueRecipient struct {
+	Name        string `json:"name,omitempty"        xml:"name,attr,omitempty"`
+	CustomKey   string `json:"customKey,omitempty"   xml:"customKey,attr,omitempty"`
+	CustomValue string `json:"customValue,omitempty" xml:"customValue,attr,omitempty"`
+	Type        string `json:"type,omitempty"        xml:"type,attr,omitempty"`
+	Address     string `json:"address,omitempty"     xml:"address,attr,omitempty"`
+	Split       string `json:"split,omitempty"       xml:"split,attr,omitempty"`
+	Fee         string `json:"fee,omitempty"         xml:"fee,attr,omitempty"`
+}
+
+// PodcastTranscript is a link to a transcript of the episode.
+type PodcastTranscript struct {
+	URL      string `json:"url,omitempty"      xml:"url,attr,omitempty"`
+	Type     string `json:"type,omitempty"     xml:"type,attr,omitempty"`
+	Language string `json:"language,omitempty" xml:"language,attr,omitempty"`
+	Rel      string `json:"rel,omitempty"      xml:"rel,attr,omitempty"`
+}
+
+// PodcastChapters is a link to the chapters of the episode.
+type PodcastChapters struct {
+	URL  string `json:"url,omitempty"  xml:"url,attr,omitempty"`
+	Type string `json:"type,omitempty" xml:"type,attr,omitempty"`
+}
+
+// PodcastSoundbite is a part of the episode suitable
+// for use as a preview.
+type PodcastSoundbite struct {
+	StartTime string `json:"startTime,omitempty" xml:"startTime,attr,omitempty"`
+	Duration  string `json:"duration,omitempty"  xml:"duration,attr,omitempty"`
+	Title     string `json:"title,omitempty"     xml:",chardata"`
+}
+
+// PodcastSeason is the season the episode belongs to.
+type PodcastSeason struct {
+	Number string `json:"number,omitempty" xml:",chardata"`
+	Name   string `json:"name,omitempty"   xml:"name,attr,omitempty"`
+}
+
+// PodcastEpisode is the number of the episode.
+type PodcastEpisode struct {
+	Number  string `json:"number,omitempty"  xml:",chardata"`
+	Display string `json:"display,omitempty" xml:"display,attr,omitempty"`
+}
+
+// PodcastAlternateEnclosure is another version of the
+// media file of the episode.
+type PodcastAlternateEnclosure struct {
+	Type      string            `json:"type,omitempty"      xml:"type,attr,omitempty"`
+	Length    string            `json:"length,omitempty"    xml:"length,attr,omitempty"`
+	Bitrate   string            `json:"bitrate,omitempty"   xml:"bitrate,attr,omitempty"`
+	Height    string            `json:"height,omitempty"    xml:"height,attr,omitempty"`
+	Lang      string            `json:"lang,omitempty"      xml:"lang,attr,omitempty"`
+	Title     string            `json:"title,omitempty"     xml:"title,attr,omitempty"`
+	Rel       string            `json:"rel,omitempty"       xml:"rel,attr,omitempty"`
+	Codecs    string            `json:"codecs,omitempty"    xml:"codecs,attr,omitempty"`
+	Default   string            `json:"default,omitempty"   xml:"default,attr,omitempty"`
+	Sources   []*PodcastSource  `json:"sources,omitempty"   xml:"podcast:source,omitempty"`
+	Integrity *PodcastIntegrity `json:"integrity,omitempty" xml:"podcast:integrity,omitempty"`
+}
+
+// PodcastSource is a location the alternate enclosure
+// can be fetched from.
+type PodcastSource struct {
+	URI         string `json:"uri,omitempty"         xml:"uri,attr,omitempty"`
+	ContentType string `json:"contentType,omitempty" xml:"contentType,attr,omitempty"`
+}
+
+// PodcastIntegrity is used to verify the alternate enclosure.
+type PodcastIntegrity struct {
+	Type  string `json:"type,omitempty"  xml:"type,attr,omitempty"`
+	Value string `json:"value,omitempty" xml:"value,attr,omitempty"`
+}
+
+// NewPodcastFeedExtension creates a PodcastFeedExtension given an
+// extension map for the "podcast" key.
+func NewPodcastFeedExtension(extensions map[string][]Extension) *PodcastFeedExtension {
+	feed := &PodcastFeedExtension{}
+	if locked := firstExtension("locked", extensions); locked != nil {
+		feed.Locked = &PodcastLocked{Owner: locked.Attrs["owner"], Value: locked.Value}
+	}
+	for _, f := range extensions["funding"] {
+		feed.Funding = append(feed.Funding, &PodcastFunding{URL: f.Attrs["url"], Value: f.Value})
+	}
+	feed.Persons = parsePodcastPersons(extensions)
+	feed.Location = parsePodcastLocation(extensions)
+	feed.Value = parsePodcastValue(extensions)
+	feed.GUID = parseTextExtension("guid", extensions)
+	feed.Medium = parseTextExtension("medium", extensions)
+	return feed
+}
+
+// NewPodcastItemExtension creates a PodcastItemExtension given an
+// extension map for the "podcast" key.
+func NewPodcastItemExtension(extensions map[string][]Extension) *PodcastItemExtension {
+	item := &PodcastItemExtension{}
+	for _, t := range extensions["transcript"] {
+		item.Transcripts = append(item.Transcripts, &PodcastTranscript{
+			URL:      t.Attrs["url"],
+			Type:     t.Attrs["type"],
+			Language: t.Attrs["language"],
+			Rel:      t.Attrs["rel"],
+		})
+	}
+	if chapters := firstExtension("chapters", extensions); chapters != nil {
+		item.Chapters = &PodcastChapters{URL: chapters.Attrs["url"], Type: chapters.Attrs["type"]}
+	}
+	for _, s := range extensions["soundbite"] {
+		item.Soundbites = append(item.Soundbites, &PodcastSoundbite{
+			StartTime: s.Attrs["startTime"],
+			Duration:  s.Attrs["duration"],
+			Title:     s.Value,
+		})
+	}
+	item.Persons = parsePodcastPersons(extensions)
+	item.Location = parsePodcastLocation(extensions)
+	if season := firstExtension("season", extensions); season != nil {
+		item.Season = &PodcastSeason{Number: season.Value, Name: season.Attrs["name"]}
+	}
+	if episode := firstExtension("episode", extensions); episode != nil {
+		item.Episode = &PodcastEpisode{Number: episode.Value, Display: episode.Attrs["display"]}
+	}
+	item.Value = parsePodcastValue(extensions)
+	for _, a := range extensions["alternateEnclosure"] {
+		item.AlternateEnclosures = append(item.AlternateEnclosures, parsePodcastAlternateEnclosure(a))
+	}
+	return item
+}
+
+func parsePodcastPersons(extensions map[string][]Extension) (persons []*PodcastPerson) {
+	for _, p := range extensions["person"] {
+		persons = append(persons, &PodcastPerson{
+			Name:  p.Value,
+			Role:  p.Attrs["role"],
+			Group: p.Attrs["group"],
+			Img:   p.Attrs["img"],
+			Href:  p.Attrs["href"],
+		})
+	}
+	return
+}
+
+func parsePodcastLocation(extensions map[string][]Extension) *PodcastLocation {
+	location := firstExtension("location", extensions)
+	if location == nil {
+		return nil
+	}
+	return &PodcastLocation{Name: location.Value, Geo: location.Attrs["geo"], OSM: location.Attrs["osm"]}
+}
+
+func parsePodcastValue(extensions map[string][]Extension) *PodcastValue {
+	v := firstExtension("value", extensions)
+	if v == nil {
+		return nil
+	}
+
+	value := &PodcastValue{
+		Type:      v.Attrs["type"],
+		Method:    v.Attrs["method"],
+		Suggested: v.Attrs["suggested"],
+	}
+	for _, r := range v.Children["valueRecipient"] {
+		value.Recipients = append(value.Recipients, &PodcastValueRecipient{
+			Name:        r.Attrs["name"],
+			CustomKey:   r.Attrs["customKey"],
+			CustomValue: r.Attrs["customValue"],
+			Type:        r.Attrs["type"],
+			Address:     r.Attrs["address"],
+			Split:       r.Attrs["split"],
+			Fee:         r.Attrs["fee"],
+		})
+	}
+	return value
+}
+
+func parsePodcastAlternateEnclosure(a Extension) *PodcastAlternateEnclosure {
+	enclosure := &PodcastAlternateEnclosure{
+		Type:    a.Attrs["type"],
+		Length:  a.Attrs["length"],
+		Bitrate: a.Attrs["bitrate"],
+		Height:  a.Attrs["height"],
+		Lang:    a.Attrs["lang"],
+		Title:   a.Attrs["title"],
+		Rel:     a.Attrs["rel"],
+		Codecs:  a.Attrs["codecs"],
+		Default: a.Attrs["default"],
+	}
+	for _, s := range a.Children["source"] {
+		enclosure.Sources = append(enclosure.Sources, &PodcastSource{URI: s.Attrs["uri"], ContentType: s.Attrs["contentType"]})
+	}
+	if integrity, ok := a.Children["integrity"]; ok && len(integrity) > 0 {
+		enclosure.Integrity = &PodcastIntegrity{Type: integrity[0].Attrs["type"], Value: integrity[0].Attrs["value"]}
+	}
+	return enclosure
+}
+
+func firstExtension(name string, extensions map[string][]Extension) *Extension {
+	matches, ok := extensions[name]
+	if !ok || len(matches) == 0 {
+		return nil
+	}
+	return &matches[0]
+}
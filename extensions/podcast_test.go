@@ -0,0 +1,75 @@
+package ext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPodcast_Extensions(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/extensions/podcast/*.xml")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		fmt.Printf("Testing %s... ", name)
+
+		// Get actual source feed
+		ff := fmt.Sprintf("../testdata/extensions/podcast/%s.xml", name)
+		f, _ := ioutil.ReadFile(ff)
+
+		// Parse actual feed
+		fp := gofeed.NewParser()
+		actual, _ := fp.Parse(bytes.NewReader(f))
+
+		// Get json encoded expected feed result
+		ef := fmt.Sprintf("../testdata/extensions/podcast/%s.json", name)
+		e, _ := ioutil.ReadFile(ef)
+
+		// Unmarshal expected feed
+		expected := &gofeed.Feed{}
+		json.Unmarshal(e, &expected)
+
+		if assert.Equal(t, expected, actual, "Feed file %s.xml did not match expected output %s.json", name, name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestPodcast_Encode(t *testing.T) {
+	data, _ := ioutil.ReadFile("../testdata/extensions/podcast/podcast_namespace.xml")
+	fp := &rss.Parser{}
+	expected, err := fp.Parse(bytes.NewReader(data))
+	require.Nil(t, err)
+
+	// Only the typed extensions are left to be encoded
+	feed := rss.Feed{
+		Title:      "Podcast",
+		PodcastExt: expected.PodcastExt,
+		Items:      []*rss.Item{{Title: "Episode", PodcastExt: expected.Items[0].PodcastExt}},
+	}
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	assert.Contains(t, string(output), `xmlns:podcast="https://podcastindex.org/namespace/1.0"`)
+	assert.Contains(t, string(output), `<podcast:valueRecipient name="App" type="node" address="03ae9f91a0cb8ff43840e3c322c4c61f019d8c1c3cea15a25cfc425ac605e61a4a" split="10" fee="true"></podcast:valueRecipient>`)
+
+	fp = &rss.Parser{}
+	actual, err := fp.Parse(bytes.NewReader(output))
+	require.Nil(t, err)
+	assert.Equal(t, expected.PodcastExt, actual.PodcastExt)
+	assert.Equal(t, expected.Items[0].PodcastExt, actual.Items[0].PodcastExt)
+	assert.Equal(t, &ext.PodcastEpisode{Number: "3", Display: "Ch.3"}, actual.Items[0].PodcastExt.Episode)
+}
@@ -0,0 +1,404 @@
+package ext
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Match is a single result of an extension query.  Extension is
+// the matched element, or nil when the query selected an
+// attribute.  Value is the value of the element or attribute.
+type Match struct {
+	Extension *Extension
+	Value     string
+}
+
+// Matches are the results of an extension query.  Children are
+// matched in document order when it was recorded by the parser.
+type Matches []Match
+
+// String returns the value of the first match, or an empty
+// string if there are no matches.
+func (m Matches) String() string {
+	if len(m) == 0 {
+		return ""
+	}
+	return m[0].Value
+}
+
+// Strings returns the values of all the matches.
+func (m Matches) Strings() []string {
+	values := make([]string, 0, len(m))
+	for _, match := range m {
+		values = append(values, match.Value)
+	}
+	return values
+}
+
+// Extensions returns the elements of all the matches which
+// are elements.
+func (m Matches) Extensions() []Extension {
+	elements := []Extension{}
+	for _, match := range m {
+		if match.Extension != nil {
+			elements = append(elements, *match.Extension)
+		}
+	}
+	return elements
+}
+
+// Query selects extension elements or attributes with a path such
+// as "media:group/media:content[@medium='video']/@url".
+//
+// A path is a list of steps separated by slashes.  Each step is an
+// element name, optionally with a prefix, and "*" matches any name
+// or any prefix.  A step without a prefix matches elements of any
+// prefix.  Steps may be followed by predicates: [@attr] requires the
+// attribute, [@attr='value'] requires the attribute value, and [n]
+// selects the nth of all the elements matched so far by the step,
+// counting from 1.  The last step may be an attribute, such as @url
+// or @xml:lang, to select attribute values instead of elements.
+//
+// Missing elements result in no matches rather than an error; an
+// error is returned only for an invalid path.
+func (e Extensions) Query(path string) (Matches, error) {
+	steps, attr, err := parseQuery(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var current []located
+	first := steps[0]
+	for _, prefix := range sortedKeys(e) {
+		if !first.matchesPrefix(prefix) {
+			continue
+		}
+		elements := e[prefix]
+		for _, name := range sortedNames(elements) {
+			if !first.matchesName(name) {
+				continue
+			}
+			for i := range elements[name] {
+				current = append(current, located{&elements[name][i], prefix})
+			}
+		}
+	}
+	current = first.filter(current)
+
+	return query(current, steps[1:], attr), nil
+}
+
+// Query selects the descendants or attributes of the extension
+// element with a path relative to the element, as described for
+// Extensions.Query.  A path of only an attribute, such as "@url",
+// selects an attribute of the element itself.
+func (e Extension) Query(path string) (Matches, error) {
+	current := []located{{&e, e.Prefix}}
+	if strings.HasPrefix(path, "@") {
+		attr, err := parseQueryName(path[1:])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid query %q: %s", path, err)
+		}
+		return query(current, nil, &attr), nil
+	}
+
+	steps, attr, err := parseQuery(path)
+	if err != nil {
+		return nil, err
+	}
+	return query(current, steps, attr), nil
+}
+
+// located is an element together with the prefix it was found
+// under, which children inherit when they have no prefix of
+// their own.
+type located struct {
+	element *Extension
+	prefix  string
+}
+
+func query(current []located, steps []queryStep, attr *queryName) Matches {
+	for _, step := range steps {
+		var next []located
+		for _, l := range current {
+			for _, child := range children(l) {
+				if step.matchesPrefix(child.prefix) && step.matchesName(child.element.Name) {
+					next = append(next, child)
+				}
+			}
+		}
+		current = step.filter(next)
+	}
+
+	matches := Matches{}
+	for _, l := range current {
+		if attr == nil {
+			matches = append(matches, Match{Extension: l.element, Value: l.element.Value})
+		} else if value, ok := attr.lookup(l); ok {
+			matches = append(matches, Match{Value: value})
+		}
+	}
+	return matches
+}
+
+// children returns the child elements in document order when it
+// is known, and sorted by name otherwise.
+func children(l located) (result []located) {
+	e := l.element
+	if len(e.Content) > 0 {
+		for _, node := range e.Content {
+			if node.Child == "" || node.Index < 0 || node.Index >= len(e.Children[node.Child]) {
+				continue
+			}
+			child := &e.Children[node.Child][node.Index]
+			result = append(result, located{child, child.prefix(l.prefix)})
+		}
+		return
+	}
+
+	for _, name := range sortedNames(e.Children) {
+		for i := range e.Children[name] {
+			child := &e.Children[name][i]
+			result = append(result, located{child, child.prefix(l.prefix)})
+		}
+	}
+	return
+}
+
+func sortedNames(elements map[string][]Extension) []string {
+	names := make([]string, 0, len(elements))
+	for name := range elements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// queryName is an element or attribute name of a query, where an
+// empty prefix matches any prefix for elements, and no prefix for
+// attributes.
+type queryName struct {
+	prefix string
+	name   string
+}
+
+func (n queryName) lookup(l located) (string, bool) {
+	e := l.element
+	if len(e.Attributes) == 0 {
+		if n.prefix != "" {
+			return "", false
+		}
+		value, ok := e.Attrs[n.name]
+		return value, ok
+	}
+	for _, attr := range e.Attributes {
+		if attr.Name == n.name && attr.Prefix == n.prefix {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+type queryPredicate struct {
+	attr     *queryName
+	value    string
+	hasValue bool
+	position int
+}
+
+type queryStep struct {
+	queryName
+	predicates []queryPredicate
+}
+
+func (s queryStep) matchesPrefix(prefix string) bool {
+	return s.prefix == "" || s.prefix == "*" || s.prefix == prefix
+}
+
+func (s queryStep) matchesName(name string) bool {
+	return s.name == "*" || s.name == name
+}
+
+// filter applies the predicates of the step, in order, to the
+// elements matched by the step.
+func (s queryStep) filter(elements []located) []located {
+	for _, p := range s.predicates {
+		if p.position > 0 {
+			if p.position > len(elements) {
+				return nil
+			}
+			elements = elements[p.position-1 : p.position]
+			continue
+		}
+
+		var kept []located
+		for _, l := range elements {
+			value, ok := p.attr.lookup(l)
+			if ok && (!p.hasValue || value == p.value) {
+				kept = append(kept, l)
+			}
+		}
+		elements = kept
+	}
+	return elements
+}
+
+// parseQuery splits a query path into its element steps and the
+// attribute selected by the last step, if any.
+func parseQuery(path string) (steps []queryStep, attr *queryName, err error) {
+	parts, err := splitQuery(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, part := range parts {
+		if strings.HasPrefix(part, "@") {
+			if i != len(parts)-1 || i == 0 {
+				return nil, nil, fmt.Errorf("Invalid query %q: attributes must follow an element", path)
+			}
+			name, err := parseQueryName(part[1:])
+			if err != nil {
+				return nil, nil, fmt.Errorf("Invalid query %q: %s", path, err)
+			}
+			attr = &name
+			break
+		}
+
+		step, err := parseQueryStep(part)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid query %q: %s", path, err)
+		}
+		steps = append(steps, step)
+	}
+	return steps, attr, nil
+}
+
+// splitQuery splits a path on the slashes which are outside of
+// predicates.
+func splitQuery(path string) ([]string, error) {
+	var parts []string
+	var quote rune
+	depth := 0
+	start := 0
+	for i, r := range path {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("Invalid query %q: unexpected ]", path)
+			}
+		case r == '/' && depth == 0:
+			parts = append(parts, path[start:i])
+			start = i + 1
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, fmt.Errorf("Invalid query %q: unterminated predicate", path)
+	}
+	parts = append(parts, path[start:])
+
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return nil, fmt.Errorf("Invalid query %q: empty step", path)
+		}
+	}
+	return parts, nil
+}
+
+func parseQueryStep(part string) (step queryStep, err error) {
+	open := strings.IndexByte(part, '[')
+	if open < 0 {
+		open = len(part)
+	}
+	if step.queryName, err = parseQueryName(part[:open]); err != nil {
+		return step, err
+	}
+
+	rest := part[open:]
+	for rest != "" {
+		end := predicateEnd(rest)
+		if rest[0] != '[' || end < 0 {
+			return step, fmt.Errorf("malformed predicate in %q", part)
+		}
+		predicate, err := parseQueryPredicate(strings.TrimSpace(rest[1:end]))
+		if err != nil {
+			return step, err
+		}
+		step.predicates = append(step.predicates, predicate)
+		rest = rest[end+1:]
+	}
+	return step, nil
+}
+
+// predicateEnd returns the index of the ] which closes the
+// predicate at the start of s, skipping quoted values.
+func predicateEnd(s string) int {
+	var quote rune
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseQueryPredicate(s string) (p queryPredicate, err error) {
+	if !strings.HasPrefix(s, "@") {
+		position, err := strconv.Atoi(s)
+		if err != nil || position < 1 {
+			return p, fmt.Errorf("unsupported predicate [%s]", s)
+		}
+		p.position = position
+		return p, nil
+	}
+
+	name := s[1:]
+	if eq := strings.IndexByte(s, '='); eq >= 0 {
+		name = s[1:eq]
+		value := strings.TrimSpace(s[eq+1:])
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return p, fmt.Errorf("attribute value must be quoted in [%s]", s)
+		}
+		p.value = value[1 : len(value)-1]
+		p.hasValue = true
+	}
+
+	attr, err := parseQueryName(strings.TrimSpace(name))
+	if err != nil {
+		return p, err
+	}
+	p.attr = &attr
+	return p, nil
+}
+
+func parseQueryName(s string) (n queryName, err error) {
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		n.prefix, n.name = s[:i], s[i+1:]
+		if n.prefix == "" {
+			return n, fmt.Errorf("empty prefix in %q", s)
+		}
+	} else {
+		n.name = s
+	}
+	if n.name == "" || strings.ContainsAny(n.name, " :[]@'\"") {
+		return n, fmt.Errorf("invalid name %q", s)
+	}
+	return n, nil
+}
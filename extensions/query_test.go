@@ -0,0 +1,104 @@
+package ext_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const queryFeed = `<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:x="http://example.com/ns/x">
+<channel>
+<title>Title</title>
+<item>
+<title>Item</title>
+<media:group>
+  <media:content url="http://example.com/a.jpg" medium="image"/>
+  <media:content url="http://example.com/a.mp4" medium="video" xml:lang="en"/>
+  <media:content url="http://example.com/b.mp4" medium="video"/>
+  <x:note>Note</x:note>
+</media:group>
+<media:title>Title</media:title>
+</item>
+</channel>
+</rss>`
+
+func TestExtensions_Query(t *testing.T) {
+	feed, err := gofeed.NewParser().Parse(strings.NewReader(queryFeed))
+	require.Nil(t, err)
+	extensions := feed.Items[0].Extensions
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"media:group/media:content[@medium='video']/@url", []string{"http://example.com/a.mp4", "http://example.com/b.mp4"}},
+		{`media:group/media:content[@medium="video"][2]/@url`, []string{"http://example.com/b.mp4"}},
+		{"media:group/media:content[1]/@url", []string{"http://example.com/a.jpg"}},
+		{"media:group/media:content[@xml:lang]/@url", []string{"http://example.com/a.mp4"}},
+		{"media:group/media:content/@xml:lang", []string{"en"}},
+		{"media:group/content/@medium", []string{"image", "video", "video"}},
+		{"media:group/x:note", []string{"Note"}},
+		{"media:group/media:note", []string{}},
+		{"media:*/*/@url", []string{"http://example.com/a.jpg", "http://example.com/a.mp4", "http://example.com/b.mp4"}},
+		{"title", []string{"Title"}},
+		{"media:missing/media:content/@url", []string{}},
+		{"media:group/media:content[4]", []string{}},
+		{"media:group/media:content/@missing", []string{}},
+	}
+
+	for _, test := range tests {
+		matches, err := extensions.Query(test.path)
+		assert.Nil(t, err, test.path)
+		assert.Equal(t, test.expected, matches.Strings(), test.path)
+	}
+}
+
+func TestExtensions_QueryErrors(t *testing.T) {
+	for _, path := range []string{
+		"",
+		"@url",
+		"media:group//media:content",
+		"media:group/@url/media:content",
+		"media:group[@medium='video'",
+		"media:group[@medium=video]",
+		"media:group[0]",
+		"media:group[last()]",
+		":group",
+	} {
+		_, err := ext.Extensions{}.Query(path)
+		assert.NotNil(t, err, path)
+	}
+}
+
+func TestExtension_Query(t *testing.T) {
+	feed, err := gofeed.NewParser().Parse(strings.NewReader(queryFeed))
+	require.Nil(t, err)
+
+	matches, err := feed.Items[0].Extensions.Query("media:group")
+	require.Nil(t, err)
+	require.Len(t, matches.Extensions(), 1)
+	group := matches.Extensions()[0]
+
+	matches, err = group.Query("media:content[@medium='image']")
+	assert.Nil(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "content", matches[0].Extension.Name)
+
+	url, err := matches[0].Extension.Query("@url")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://example.com/a.jpg", url.String())
+
+	hand := ext.Extension{Name: "content", Attrs: map[string]string{"url": "http://example.com/c.jpg"}}
+	url, err = hand.Query("@url")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://example.com/c.jpg", url.String())
+
+	missing, err := hand.Query("media:thumbnail/@url")
+	assert.Nil(t, err)
+	assert.Equal(t, "", missing.String())
+	assert.Empty(t, missing.Extensions())
+}
@@ -0,0 +1,152 @@
+package ext
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/mmcdole/gofeed/internal/namespaces"
+)
+
+// ErrExtensionNotFound is returned by Extensions.Decode when
+// none of the registered extensions decode into the given type
+var ErrExtensionNotFound = errors.New("Extension not found")
+
+// Decoder converts the extension elements of a namespace, keyed
+// by element name, into a typed value.  A decoder which fails
+// should return a nil value of its type along with the error,
+// such as (*Weather)(nil), so that the error is only reported
+// to callers decoding that type.
+type Decoder func(extensions map[string][]Extension) (interface{}, error)
+
+type registration struct {
+	namespace string
+	prefix    string
+	decode    Decoder
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]registration{}
+)
+
+// Register adds a decoder for the extension elements in the
+// given namespace URI.  The elements are parsed under prefix,
+// which overrides the prefix that a feed declares for the
+// namespace, and can then be decoded from the Extensions of
+// a feed or item with Extensions.Decode.
+//
+// Register is intended to be called from an init function.
+// Registering a namespace again replaces its decoder.  The
+// canonical prefixes of the built in namespaces, such as
+// media for Media RSS, cannot be registered for any other
+// namespace.  Giving one of the built in namespaces a prefix
+// other than its usual one means the built in typed extension
+// will no longer be populated.
+func Register(namespace, prefix string, decode Decoder) error {
+	if namespace == "" || prefix == "" {
+		return fmt.Errorf("Namespace and prefix must not be empty")
+	}
+	if decode == nil {
+		return fmt.Errorf("Decoder for %s must not be nil", namespace)
+	}
+
+	if namespaces.Canonical[namespace] != prefix {
+		for _, p := range namespaces.Canonical {
+			if p == prefix {
+				return fmt.Errorf("Prefix %s is reserved for a built in namespace", prefix)
+			}
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for ns, r := range registry {
+		if r.prefix == prefix && ns != namespace {
+			return fmt.Errorf("Prefix %s is already registered for %s", prefix, ns)
+		}
+	}
+	registry[namespace] = registration{namespace: namespace, prefix: prefix, decode: decode}
+	return nil
+}
+
+// RegisteredPrefix returns the prefix that the namespace was
+// registered with, if any.
+func RegisteredPrefix(namespace string) (prefix string, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	r, ok := registry[namespace]
+	return r.prefix, ok
+}
+
+// RegisteredNamespace returns the namespace that was
+// registered with the prefix, if any.
+func RegisteredNamespace(prefix string) (namespace string, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, r := range registry {
+		if r.prefix == prefix {
+			return r.namespace, true
+		}
+	}
+	return "", false
+}
+
+// Decode decodes the registered extension whose decoder returns
+// a value of the type pointed to by v, and stores it in v.  It
+// returns ErrExtensionNotFound if the extensions contain no
+// elements for a registered namespace of that type.  The error
+// of a failing decoder is returned if its value is of that type,
+// or if it returned no value and no other decoder succeeded.
+func (e Extensions) Decode(v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("Decode requires a non-nil pointer, got %T", v)
+	}
+	elem := target.Elem()
+
+	var firstErr error
+	for _, r := range registrations() {
+		elements, ok := e[r.prefix]
+		if !ok {
+			continue
+		}
+
+		value, err := r.decode(elements)
+		if value == nil {
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		decoded := reflect.ValueOf(value)
+		if !decoded.Type().AssignableTo(elem.Type()) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		elem.Set(decoded)
+		return nil
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return ErrExtensionNotFound
+}
+
+// registrations returns the registered extensions ordered
+// by prefix so that decoding is deterministic.
+func registrations() []registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	result := make([]registration, 0, len(registry))
+	for _, r := range registry {
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].prefix < result[j].prefix })
+	return result
+}
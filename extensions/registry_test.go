@@ -0,0 +1,131 @@
+package ext_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type weather struct {
+	Conditions  string
+	Temperature float64
+}
+
+const weatherNS = "http://example.com/ns/weather"
+
+func init() {
+	err := ext.Register(weatherNS, "weather", func(extensions map[string][]ext.Extension) (interface{}, error) {
+		w := &weather{}
+		if c, ok := extensions["conditions"]; ok {
+			w.Conditions = c[0].Value
+		}
+		if t, ok := extensions["temperature"]; ok {
+			temperature, err := strconv.ParseFloat(t[0].Value, 64)
+			if err != nil {
+				return nil, err
+			}
+			w.Temperature = temperature
+		}
+		return w, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// alert is decoded before weather, and always fails
+	err = ext.Register(alertNS, "alert", func(extensions map[string][]ext.Extension) (interface{}, error) {
+		return (*alert)(nil), errors.New("Invalid alert")
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+type alert struct {
+	Level string
+}
+
+const alertNS = "http://example.com/ns/alert"
+
+const weatherFeed = `<rss version="2.0" xmlns:w="http://example.com/ns/weather">
+<channel>
+<title>Forecast</title>
+<item>
+<title>Today</title>
+<w:conditions>Sunny</w:conditions>
+<a:level xmlns:a="http://example.com/ns/alert">high</a:level>
+<w:temperature>21.5</w:temperature>
+</item>
+<item>
+<title>Tomorrow</title>
+<w:temperature>warm</w:temperature>
+</item>
+</channel>
+</rss>`
+
+func TestRegister_Decode(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(weatherFeed)
+	require.Nil(t, err)
+	require.Len(t, feed.Items, 2)
+
+	// The registered prefix replaces the one declared by the feed
+	assert.Contains(t, feed.Items[0].Extensions, "weather")
+	assert.NotContains(t, feed.Items[0].Extensions, "w")
+
+	var w *weather
+	require.Nil(t, feed.Items[0].Extensions.Decode(&w))
+	assert.Equal(t, &weather{Conditions: "Sunny", Temperature: 21.5}, w)
+
+	// A failing decoder of another type does not stop the
+	// requested type from being decoded, but is reported
+	// when nothing of the requested type could be decoded
+	var a *alert
+	assert.EqualError(t, feed.Items[0].Extensions.Decode(&a), "Invalid alert")
+
+	// Errors from the decoder are returned as is
+	assert.NotNil(t, feed.Items[1].Extensions.Decode(&w))
+
+	// The feed has no weather elements
+	assert.Equal(t, ext.ErrExtensionNotFound, feed.Extensions.Decode(&w))
+
+	// No registered decoder returns a string
+	var s string
+	assert.Equal(t, ext.ErrExtensionNotFound, feed.Items[0].Extensions.Decode(&s))
+
+	assert.NotNil(t, feed.Items[0].Extensions.Decode(w))
+}
+
+func TestRegister_RawFeed(t *testing.T) {
+	fp := &rss.Parser{}
+	feed, err := fp.Parse(bytes.NewReader([]byte(weatherFeed)))
+	require.Nil(t, err)
+
+	var w *weather
+	require.Nil(t, feed.Items[0].Extensions.Decode(&w))
+	assert.Equal(t, "Sunny", w.Conditions)
+
+	// The registered namespace is declared when the feed is written
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	assert.Contains(t, string(output), `xmlns:weather="http://example.com/ns/weather"`)
+	assert.Contains(t, string(output), `<weather:conditions>Sunny</weather:conditions>`)
+}
+
+func TestRegister_Invalid(t *testing.T) {
+	decode := func(map[string][]ext.Extension) (interface{}, error) { return nil, nil }
+	assert.NotNil(t, ext.Register("", "x", decode))
+	assert.NotNil(t, ext.Register("http://example.com/ns/x", "", decode))
+	assert.NotNil(t, ext.Register("http://example.com/ns/x", "x", nil))
+	assert.NotNil(t, ext.Register("http://example.com/ns/other", "weather", decode))
+
+	// Canonical prefixes of built in namespaces are reserved
+	assert.NotNil(t, ext.Register("http://example.com/ns/other", "media", decode))
+	assert.NotNil(t, ext.Register("http://example.com/ns/other", "dc", decode))
+}
@@ -0,0 +1,72 @@
+package ext
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyndicationExtension is a set of extension fields from the
+// RSS 1.0 Syndication module
+// (http://purl.org/rss/1.0/modules/syndication/) which describe
+// how often a feed is updated.
+type SyndicationExtension struct {
+	UpdatePeriod    string `json:"updatePeriod,omitempty"`
+	UpdateFrequency string `json:"updateFrequency,omitempty"`
+	UpdateBase      string `json:"updateBase,omitempty"`
+}
+
+// Encode will encode the syndication extension in the provided xml encoder
+func (sy SyndicationExtension) Encode(e *xml.Encoder) error {
+	encode(e, "sy:updatePeriod", sy.UpdatePeriod)
+	encode(e, "sy:updateFrequency", sy.UpdateFrequency)
+	encode(e, "sy:updateBase", sy.UpdateBase)
+	return nil
+}
+
+// syndicationPeriods are the lengths of the update periods
+// defined by the Syndication module
+var syndicationPeriods = map[string]time.Duration{
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+	"yearly":  365 * 24 * time.Hour,
+}
+
+// Interval returns the expected time between updates of the
+// feed, which is the update period divided by the update
+// frequency. As in the Syndication module, the period defaults
+// to daily and the frequency to 1. It returns 0 if the period
+// is not one of hourly, daily, weekly, monthly or yearly, or if
+// the extension sets neither the period nor the frequency.
+func (sy SyndicationExtension) Interval() time.Duration {
+	if sy.UpdatePeriod == "" && sy.UpdateFrequency == "" {
+		return 0
+	}
+
+	period := strings.ToLower(strings.TrimSpace(sy.UpdatePeriod))
+	if period == "" {
+		period = "daily"
+	}
+	interval, ok := syndicationPeriods[period]
+	if !ok {
+		return 0
+	}
+
+	if frequency, err := strconv.Atoi(strings.TrimSpace(sy.UpdateFrequency)); err == nil && frequency > 1 {
+		interval /= time.Duration(frequency)
+	}
+	return interval
+}
+
+// NewSyndicationExtension creates a SyndicationExtension given
+// the generic extension map for the "sy" prefix.
+func NewSyndicationExtension(extensions map[string][]Extension) *SyndicationExtension {
+	sy := &SyndicationExtension{}
+	sy.UpdatePeriod = parseTextExtension("updatePeriod", extensions)
+	sy.UpdateFrequency = parseTextExtension("updateFrequency", extensions)
+	sy.UpdateBase = parseTextExtension("updateBase", extensions)
+	return sy
+}
@@ -0,0 +1,29 @@
+package ext_test
+
+import (
+	"testing"
+	"time"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyndication_Interval(t *testing.T) {
+	tests := []struct {
+		sy       ext.SyndicationExtension
+		expected time.Duration
+	}{
+		{ext.SyndicationExtension{UpdatePeriod: "hourly"}, time.Hour},
+		{ext.SyndicationExtension{UpdatePeriod: "hourly", UpdateFrequency: "2"}, 30 * time.Minute},
+		{ext.SyndicationExtension{UpdatePeriod: " Weekly "}, 7 * 24 * time.Hour},
+		{ext.SyndicationExtension{UpdateFrequency: "4"}, 6 * time.Hour},
+		{ext.SyndicationExtension{UpdatePeriod: "daily", UpdateFrequency: "0"}, 24 * time.Hour},
+		{ext.SyndicationExtension{UpdatePeriod: "daily", UpdateFrequency: "often"}, 24 * time.Hour},
+		{ext.SyndicationExtension{UpdatePeriod: "fortnightly"}, 0},
+		{ext.SyndicationExtension{UpdateBase: "2000-01-01T12:00+00:00"}, 0},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.sy.Interval(), "%+v", test.sy)
+	}
+}
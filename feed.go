@@ -13,29 +13,35 @@ import (
 // Sorting with sort.Sort will order the Items by
 // oldest to newest publish time.
 type Feed struct {
-	Title           string                   `json:"title,omitempty"`
-	Description     string                   `json:"description,omitempty"`
-	Link            string                   `json:"link,omitempty"`
-	FeedLink        string                   `json:"feedLink,omitempty"`
-	Links           []string                 `json:"links,omitempty"`
-	Updated         string                   `json:"updated,omitempty"`
-	UpdatedParsed   *time.Time               `json:"updatedParsed,omitempty"`
-	Published       string                   `json:"published,omitempty"`
-	PublishedParsed *time.Time               `json:"publishedParsed,omitempty"`
-	Author          *Person                  `json:"author,omitempty"` // Deprecated: Use feed.Authors instead
-	Authors         []*Person                `json:"authors,omitempty"`
-	Language        string                   `json:"language,omitempty"`
-	Image           *Image                   `json:"image,omitempty"`
-	Copyright       string                   `json:"copyright,omitempty"`
-	Generator       string                   `json:"generator,omitempty"`
-	Categories      []string                 `json:"categories,omitempty"`
-	DublinCoreExt   *ext.DublinCoreExtension `json:"dcExt,omitempty"`
-	ITunesExt       *ext.ITunesFeedExtension `json:"itunesExt,omitempty"`
-	Extensions      ext.Extensions           `json:"extensions,omitempty"`
-	Custom          map[string]string        `json:"custom,omitempty"`
-	Items           []*Item                  `json:"items"`
-	FeedType        string                   `json:"feedType"`
-	FeedVersion     string                   `json:"feedVersion"`
+	Title              string                        `json:"title,omitempty"`
+	Description        string                        `json:"description,omitempty"`
+	Link               string                        `json:"link,omitempty"`
+	FeedLink           string                        `json:"feedLink,omitempty"`
+	Links              []string                      `json:"links,omitempty"`
+	Hubs               []string                      `json:"hubs,omitempty"`
+	Updated            string                        `json:"updated,omitempty"`
+	UpdatedParsed      *time.Time                    `json:"updatedParsed,omitempty"`
+	Published          string                        `json:"published,omitempty"`
+	PublishedParsed    *time.Time                    `json:"publishedParsed,omitempty"`
+	Author             *Person                       `json:"author,omitempty"` // Deprecated: Use feed.Authors instead
+	Authors            []*Person                     `json:"authors,omitempty"`
+	Language           string                        `json:"language,omitempty"`
+	Image              *Image                        `json:"image,omitempty"`
+	Copyright          string                        `json:"copyright,omitempty"`
+	Generator          string                        `json:"generator,omitempty"`
+	Categories         []string                      `json:"categories,omitempty"`
+	RefreshInterval    time.Duration                 `json:"refreshInterval,omitempty"`
+	DublinCoreExt      *ext.DublinCoreExtension      `json:"dcExt,omitempty"`
+	DublinCoreTermsExt *ext.DublinCoreTermsExtension `json:"dctermsExt,omitempty"`
+	ITunesExt          *ext.ITunesFeedExtension      `json:"itunesExt,omitempty"`
+	PodcastExt         *ext.PodcastFeedExtension     `json:"podcastExt,omitempty"`
+	MediaExt           *ext.MediaExtension           `json:"mediaExt,omitempty"`
+	SyndicationExt     *ext.SyndicationExtension     `json:"syExt,omitempty"`
+	Extensions         ext.Extensions                `json:"extensions,omitempty"`
+	Custom             map[string]string             `json:"custom,omitempty"`
+	Items              []*Item                       `json:"items"`
+	FeedType           string                        `json:"feedType"`
+	FeedVersion        string                        `json:"feedVersion"`
 }
 
 func (f Feed) String() string {
@@ -47,25 +53,36 @@ func (f Feed) String() string {
 // and rss.Item gets translated to.  It represents
 // a single entry in a given feed.
 type Item struct {
-	Title           string                   `json:"title,omitempty"`
-	Description     string                   `json:"description,omitempty"`
-	Content         string                   `json:"content,omitempty"`
-	Link            string                   `json:"link,omitempty"`
-	Links           []string                 `json:"links,omitempty"`
-	Updated         string                   `json:"updated,omitempty"`
-	UpdatedParsed   *time.Time               `json:"updatedParsed,omitempty"`
-	Published       string                   `json:"published,omitempty"`
-	PublishedParsed *time.Time               `json:"publishedParsed,omitempty"`
-	Author          *Person                  `json:"author,omitempty"` // Deprecated: Use item.Authors instead
-	Authors         []*Person                `json:"authors,omitempty"`
-	GUID            string                   `json:"guid,omitempty"`
-	Image           *Image                   `json:"image,omitempty"`
-	Categories      []string                 `json:"categories,omitempty"`
-	Enclosures      []*Enclosure             `json:"enclosures,omitempty"`
-	DublinCoreExt   *ext.DublinCoreExtension `json:"dcExt,omitempty"`
-	ITunesExt       *ext.ITunesItemExtension `json:"itunesExt,omitempty"`
-	Extensions      ext.Extensions           `json:"extensions,omitempty"`
-	Custom          map[string]string        `json:"custom,omitempty"`
+	Title              string                        `json:"title,omitempty"`
+	Description        string                        `json:"description,omitempty"`
+	Content            string                        `json:"content,omitempty"`
+	Link               string                        `json:"link,omitempty"`
+	Links              []string                      `json:"links,omitempty"`
+	Updated            string                        `json:"updated,omitempty"`
+	UpdatedParsed      *time.Time                    `json:"updatedParsed,omitempty"`
+	Published          string                        `json:"published,omitempty"`
+	PublishedParsed    *time.Time                    `json:"publishedParsed,omitempty"`
+	Author             *Person                       `json:"author,omitempty"` // Deprecated: Use item.Authors instead
+	Authors            []*Person                     `json:"authors,omitempty"`
+	GUID               string                        `json:"guid,omitempty"`
+	Image              *Image                        `json:"image,omitempty"`
+	Categories         []string                      `json:"categories,omitempty"`
+	Enclosures         []*Enclosure                  `json:"enclosures,omitempty"`
+	Source             *Source                       `json:"source,omitempty"`
+	Origin             *Source                       `json:"origin,omitempty"`
+	Location           *Location                     `json:"location,omitempty"`
+	License            *License                      `json:"license,omitempty"`
+	CommentsLink       string                        `json:"commentsLink,omitempty"`
+	CommentsFeed       string                        `json:"commentsFeed,omitempty"`
+	CommentCount       *int                          `json:"commentCount,omitempty"`
+	InReplyTo          []*InReplyTo                  `json:"inReplyTo,omitempty"`
+	DublinCoreExt      *ext.DublinCoreExtension      `json:"dcExt,omitempty"`
+	DublinCoreTermsExt *ext.DublinCoreTermsExtension `json:"dctermsExt,omitempty"`
+	ITunesExt          *ext.ITunesItemExtension      `json:"itunesExt,omitempty"`
+	PodcastExt         *ext.PodcastItemExtension     `json:"podcastExt,omitempty"`
+	MediaExt           *ext.MediaExtension           `json:"mediaExt,omitempty"`
+	Extensions         ext.Extensions                `json:"extensions,omitempty"`
+	Custom             map[string]string             `json:"custom,omitempty"`
 }
 
 // Person is an individual specified in a feed
@@ -89,6 +106,16 @@ type Enclosure struct {
 	Type   string `json:"type,omitempty"`
 }
 
+// Source identifies the feed that an Item
+// originally came from.  It is also used as the
+// Origin of the items of a merged feed, which is
+// the feed Merge took them from.
+type Source struct {
+	Title    string `json:"title,omitempty"`
+	Link     string `json:"link,omitempty"`
+	FeedLink string `json:"feedLink,omitempty"`
+}
+
 // Len returns the length of Items.
 func (f Feed) Len() int {
 	return len(f.Items)
@@ -0,0 +1,270 @@
+package gofeed
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// HistoryNamespace is the namespace of the fh:complete and
+// fh:archive elements of RFC 5005
+const HistoryNamespace = "http://purl.org/syndication/history/1.0"
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// historyPage is a fetched feed document and the RFC 5005
+// links and markers it carries.  JSON Feed documents carry
+// their next_url instead.
+type historyPage struct {
+	url         string
+	feed        *Feed
+	json        *json.Feed
+	next        string
+	prevArchive string
+	current     string
+	complete    bool
+	archive     bool
+}
+
+// ParseHistory reconstructs the complete history of the feed
+// at feedURL by following the feed paging and archiving links
+// of RFC 5005.
+//
+// A feed marked with fh:complete contains its whole history and
+// no links are followed.  Archived feeds are walked along their
+// rel="prev-archive" links and paged feeds along their
+// rel="next" links.  If feedURL is an archive document marked
+// with fh:archive, the walk starts from its rel="current" link.
+// JSON Feeds are walked along their next_url as JSONPages does.
+//
+// The returned feed takes its fields from the subscription
+// document and its items from every document, sorted from
+// oldest to newest by their published or updated date.  Items
+// with the same date, or without one, are ordered as the
+// documents list them, assuming each document lists its newest
+// items first.  When an entry appears in several documents the
+// copy from the newest document is kept.  If an error occurs after the first
+// document, the history fetched so far is returned along with
+// the error.
+func (f *Parser) ParseHistory(feedURL string, opts *PageOptions) (*Feed, error) {
+	return f.ParseHistoryWithContext(feedURL, opts, context.Background())
+}
+
+// ParseHistoryWithContext is like ParseHistory but the requests
+// could be canceled or timeout via given context
+func (f *Parser) ParseHistoryWithContext(feedURL string, opts *PageOptions, ctx context.Context) (*Feed, error) {
+	maxPages := opts.maxPages()
+	fetched := map[string]*historyPage{}
+	seen := map[string]bool{}
+	pages := []*historyPage{}
+
+	// fetch returns the document at pageURL, reusing the
+	// document the walk started from if it links back to it
+	fetch := func(pageURL string) (*historyPage, error) {
+		pageURL = stripFragment(pageURL)
+		if seen[pageURL] {
+			return nil, ErrPageLoop
+		}
+		seen[pageURL] = true
+		if page, ok := fetched[pageURL]; ok {
+			return page, nil
+		}
+		if len(fetched) >= maxPages {
+			return nil, ErrPageLimit
+		}
+		page, err := f.fetchHistoryPage(pageURL, ctx)
+		if err != nil {
+			return nil, err
+		}
+		fetched[pageURL] = page
+		return page, nil
+	}
+
+	page, err := fetch(feedURL)
+	if err != nil {
+		return nil, err
+	}
+	if page.json != nil {
+		pages = append(pages, page)
+		next := f.continueJSONPages(page.url, page.json, page.feed, opts, ctx)
+		for next.Next() {
+			pages = append(pages, &historyPage{url: next.URL(), feed: next.Feed()})
+		}
+		return mergeHistory(pages), next.Err()
+	}
+	if page.archive && page.current != "" && !seen[stripFragment(page.current)] {
+		current, err := fetch(page.current)
+		if err != nil {
+			return mergeHistory([]*historyPage{page}), err
+		}
+		delete(seen, page.url)
+		page = current
+	}
+
+	for {
+		pages = append(pages, page)
+		if page.complete {
+			break
+		}
+
+		link := page.prevArchive
+		if link == "" {
+			link = page.next
+		}
+		if link == "" {
+			break
+		}
+
+		page, err = fetch(link)
+		if err != nil {
+			break
+		}
+	}
+
+	return mergeHistory(pages), err
+}
+
+// mergeHistory combines the pages, which are ordered from the
+// subscription document to the oldest document, into a feed
+// with the items sorted from oldest to newest
+func mergeHistory(pages []*historyPage) *Feed {
+	result := *pages[0].feed
+	result.Items = []*Item{}
+
+	seen := map[string]bool{}
+	for _, page := range pages {
+		for _, item := range page.feed.Items {
+			if item == nil {
+				continue
+			}
+			id := item.Identity()
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			result.Items = append(result.Items, item)
+		}
+	}
+
+	for i, k := 0, len(result.Items)-1; i < k; i, k = i+1, k-1 {
+		result.Items[i], result.Items[k] = result.Items[k], result.Items[i]
+	}
+	sortOldestFirst(result.Items)
+	return &result
+}
+
+func (f *Parser) fetchHistoryPage(pageURL string, ctx context.Context) (page *historyPage, err error) {
+	var body []byte
+	_, err = f.fetchBody(pageURL, ctx, nil, func(r io.Reader) (err error) {
+		body, err = ioutil.ReadAll(r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page = &historyPage{url: pageURL}
+	links := map[string]string{}
+	var extensions ext.Extensions
+
+	switch DetectFeedType(bytes.NewReader(body)) {
+	case FeedTypeAtom:
+		af, err := f.ap.Parse(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range af.Links {
+			if _, ok := links[l.Rel]; !ok && l.Href != "" {
+				links[l.Rel] = l.Href
+			}
+		}
+		extensions = af.Extensions
+		page.feed, err = f.atomTrans().Translate(af)
+		if err != nil {
+			return nil, err
+		}
+	case FeedTypeRSS:
+		rf, err := f.rp.Parse(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range rssAtomLinks(rf) {
+			rel, href := l.Attrs["rel"], l.Attrs["href"]
+			if _, ok := links[rel]; !ok && href != "" {
+				links[rel] = href
+			}
+		}
+		extensions = rf.Extensions
+		page.feed, err = f.rssTrans().Translate(rf)
+		if err != nil {
+			return nil, err
+		}
+	case FeedTypeJSON:
+		jf, err := f.jp.Parse(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		page.json = jf
+		page.feed, err = f.jsonTrans().Translate(jf)
+		if err != nil {
+			return nil, err
+		}
+		return page, nil
+	default:
+		return nil, ErrFeedTypeNotDetected
+	}
+
+	history := extensions.Namespace(HistoryNamespace)
+	_, page.complete = history["complete"]
+	_, page.archive = history["archive"]
+
+	page.next = resolveLink(pageURL, links["next"])
+	page.prevArchive = resolveLink(pageURL, links["prev-archive"])
+	page.current = resolveLink(pageURL, links["current"])
+	return page, nil
+}
+
+// rssAtomLinks returns the atom:link elements of an RSS channel
+func rssAtomLinks(rf *rss.Feed) []ext.Extension {
+	if links := rf.Extensions.Namespace(atomNamespace)["link"]; links != nil {
+		return links
+	}
+	for _, prefix := range []string{"atom", "atom10", "atom03"} {
+		if links := rf.Extensions[prefix]["link"]; links != nil {
+			return links
+		}
+	}
+	return nil
+}
+
+// resolveLink makes href absolute against the URL of the
+// document it appears in
+func resolveLink(base, href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return u.String()
+	}
+	return b.ResolveReference(u).String()
+}
+
+func stripFragment(pageURL string) string {
+	if i := strings.Index(pageURL, "#"); i >= 0 {
+		return pageURL[:i]
+	}
+	return pageURL
+}
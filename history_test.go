@@ -0,0 +1,165 @@
+package gofeed_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func atomEntry(id, title string) string {
+	return fmt.Sprintf(`<entry><id>%s</id><title>%s</title></entry>`, id, title)
+}
+
+func atomDocument(marker string, links map[string]string, entries ...string) string {
+	doc := `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:fh="http://purl.org/syndication/history/1.0"><title>Archived</title>` + marker
+	for rel, href := range links {
+		doc += fmt.Sprintf(`<link rel="%s" href="%s"/>`, rel, href)
+	}
+	for _, entry := range entries {
+		doc += entry
+	}
+	return doc + `</feed>`
+}
+
+func rssDocument(marker string, links map[string]string, guids ...string) string {
+	doc := `<rss version="2.0" xmlns:a="http://www.w3.org/2005/Atom" xmlns:h="http://purl.org/syndication/history/1.0"><channel><title>Paged</title>` + marker
+	for rel, href := range links {
+		doc += fmt.Sprintf(`<a:link rel="%s" href="%s"/>`, rel, href)
+	}
+	for _, guid := range guids {
+		doc += fmt.Sprintf(`<item><guid>%s</guid><title>Item %s</title></item>`, guid, guid)
+	}
+	return doc + `</channel></rss>`
+}
+
+func historyIDs(feed *gofeed.Feed) []string {
+	ids := []string{}
+	for _, item := range feed.Items {
+		ids = append(ids, item.GUID)
+	}
+	return ids
+}
+
+func TestParser_ParseHistory_Archived(t *testing.T) {
+	server := pagedServer(map[string]string{
+		"/feed": atomDocument("", map[string]string{"prev-archive": "/archive/2"},
+			atomEntry("urn:5", "Five"), atomEntry("urn:4", "Four, updated")),
+		"/archive/2": atomDocument("<fh:archive/>", map[string]string{"current": "/feed", "prev-archive": "1"},
+			atomEntry("urn:4", "Four"), atomEntry("urn:3", "Three")),
+		"/archive/1": atomDocument("<fh:archive/>", map[string]string{"current": "/feed"},
+			atomEntry("urn:2", "Two"), atomEntry("urn:1", "One")),
+	})
+	defer server.Close()
+	fp := gofeed.NewParser()
+
+	for _, start := range []string{"/feed", "/archive/2"} {
+		feed, err := fp.ParseHistory(server.URL+start, nil)
+		require.Nil(t, err, start)
+		assert.Equal(t, "Archived", feed.Title)
+		assert.Equal(t, []string{"urn:1", "urn:2", "urn:3", "urn:4", "urn:5"}, historyIDs(feed), start)
+		assert.Equal(t, "Four, updated", feed.Items[3].Title)
+	}
+}
+
+func TestParser_ParseHistory_Dates(t *testing.T) {
+	dated := func(id, published string) string {
+		return fmt.Sprintf(`<entry><id>%s</id><title>%s</title><published>%s</published></entry>`, id, id, published)
+	}
+	server := pagedServer(map[string]string{
+		"/feed": atomDocument("", map[string]string{"prev-archive": "/archive"},
+			dated("urn:4", "2020-04-01T00:00:00Z"), dated("urn:5", "2020-05-01T00:00:00Z")),
+		"/archive": atomDocument("<fh:archive/>", nil,
+			dated("urn:1", "2020-01-01T00:00:00Z"), dated("urn:3", "2020-03-01T00:00:00Z"), dated("urn:2", "2020-02-01T00:00:00Z")),
+		"/undated": atomDocument("", nil,
+			atomEntry("urn:b", "B"), dated("urn:c", "2020-01-01T00:00:00Z"), atomEntry("urn:a", "A")),
+	})
+	defer server.Close()
+	fp := gofeed.NewParser()
+
+	feed, err := fp.ParseHistory(server.URL+"/feed", nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"urn:1", "urn:2", "urn:3", "urn:4", "urn:5"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/undated", nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"urn:a", "urn:b", "urn:c"}, historyIDs(feed))
+}
+
+func TestParser_ParseHistory_MissingCurrent(t *testing.T) {
+	server := pagedServer(map[string]string{
+		"/archive": atomDocument("<fh:archive/>", map[string]string{"current": "/missing"},
+			atomEntry("urn:2", "Two"), atomEntry("urn:1", "One")),
+	})
+	defer server.Close()
+
+	feed, err := gofeed.NewParser().ParseHistory(server.URL+"/archive", nil)
+	httpErr, ok := err.(gofeed.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	require.NotNil(t, feed)
+	assert.Equal(t, []string{"urn:1", "urn:2"}, historyIDs(feed))
+}
+
+func TestParser_ParseHistory_Paged(t *testing.T) {
+	server := pagedServer(map[string]string{
+		"/rss":          rssDocument("", map[string]string{"next": "/rss?page=2"}, "3"),
+		"/rss?page=2":   rssDocument("", map[string]string{"next": "/rss?page=3#more"}, "2"),
+		"/rss?page=3":   rssDocument("", nil, "1"),
+		"/complete":     rssDocument("<h:complete/>", map[string]string{"next": "/rss?page=2"}, "2", "1"),
+		"/loop":         rssDocument("", map[string]string{"next": "/loop?page=2"}, "2"),
+		"/loop?page=2":  rssDocument("", map[string]string{"next": "/loop"}, "1"),
+		"/broken":       rssDocument("", map[string]string{"next": "/missing"}, "1"),
+		"/unsupported":  `<html></html>`,
+		"/json":         jsonPage("JSON", "/json?page=2", "2"),
+		"/json?page=2":  jsonPage("JSON page 2", "", "1"),
+		"/jloop":        jsonPage("JSON loop", "/jloop?page=2", "2"),
+		"/jloop?page=2": jsonPage("JSON loop page 2", "/jloop#top", "1"),
+	})
+	defer server.Close()
+	fp := gofeed.NewParser()
+
+	feed, err := fp.ParseHistory(server.URL+"/rss", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "Paged", feed.Title)
+	assert.Equal(t, []string{"1", "2", "3"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/complete", nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/json", nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/jloop", nil)
+	assert.Equal(t, gofeed.ErrPageLoop, err)
+	assert.Equal(t, []string{"1", "2"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/json", &gofeed.PageOptions{MaxPages: 1})
+	assert.Equal(t, gofeed.ErrPageLimit, err)
+	assert.Equal(t, []string{"2"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/loop", nil)
+	assert.Equal(t, gofeed.ErrPageLoop, err)
+	require.NotNil(t, feed)
+	assert.Equal(t, []string{"1", "2"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/rss", &gofeed.PageOptions{MaxPages: 2})
+	assert.Equal(t, gofeed.ErrPageLimit, err)
+	require.NotNil(t, feed)
+	assert.Equal(t, []string{"2", "3"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/broken", nil)
+	httpErr, ok := err.(gofeed.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	assert.Equal(t, []string{"1"}, historyIDs(feed))
+
+	feed, err = fp.ParseHistory(server.URL+"/unsupported", nil)
+	assert.Equal(t, gofeed.ErrFeedTypeNotDetected, err)
+	assert.Nil(t, feed)
+}
@@ -0,0 +1,128 @@
+package gofeed
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"time"
+)
+
+// Identity returns a deterministic identifier for the item
+// that can be used to recognize it across fetches of a feed.
+//
+// The identifier is chosen with the following precedence:
+//  1. GUID (the RSS guid, Atom id or JSON Feed id)
+//  2. Link
+//  3. URL of the first enclosure
+//  4. A hash of the title and published date
+//  5. A hash of the title, description and content
+//
+// The identifier is prefixed with the kind of field it was
+// taken from ("guid:", "link:", "enclosure:" or "hash:") so
+// that a GUID which equals the link of another item does not
+// identify that item.
+func (i *Item) Identity() string {
+	if i.GUID != "" {
+		return "guid:" + i.GUID
+	}
+	return i.FallbackIdentity()
+}
+
+// FallbackIdentity returns the identifier the item would
+// have if it had no GUID.  It is useful for feeds whose GUIDs
+// are not stable across fetches (see UnstableGUIDs).
+func (i *Item) FallbackIdentity() string {
+	kind, id := i.fallback()
+	return kind + ":" + id
+}
+
+// fallback returns the unprefixed FallbackIdentity of the item
+// along with the kind of field it was taken from: "link",
+// "enclosure" or "hash"
+func (i *Item) fallback() (kind, id string) {
+	if i.Link != "" {
+		return "link", i.Link
+	}
+
+	for _, e := range i.Enclosures {
+		if e != nil && e.URL != "" {
+			return "enclosure", e.URL
+		}
+	}
+
+	published := i.Published
+	if i.PublishedParsed != nil {
+		published = i.PublishedParsed.UTC().Format(time.RFC3339)
+	}
+	if i.Title != "" && published != "" {
+		return "hash", hashFields(i.Title, published)
+	}
+
+	return "hash", hashFields(i.Title, i.Description, i.Content)
+}
+
+// UnstableGUIDs reports whether the GUIDs of a feed appear to
+// be regenerated between fetches.  Items from both fetches
+// are matched by their FallbackIdentity and the GUIDs are
+// considered unstable if any matched pair has differing GUIDs.
+func UnstableGUIDs(prev, curr *Feed) bool {
+	if prev == nil || curr == nil {
+		return false
+	}
+
+	ids, fallbacks := []string{}, []string{}
+	for _, item := range prev.Items {
+		if item != nil && item.GUID != "" {
+			ids = append(ids, item.Identity())
+			fallbacks = append(fallbacks, item.FallbackIdentity())
+		}
+	}
+	return guidsChanged(guidsByFallback(ids, fallbacks), curr.Items)
+}
+
+// guidsByFallback maps the FallbackIdentity of items with a
+// GUID to their Identity.  Fallbacks shared by several items,
+// such as the hash of items without a title or link, identify
+// none of them and are left out.
+func guidsByFallback(ids, fallbacks []string) map[string]string {
+	guids := map[string]string{}
+	shared := map[string]bool{}
+	for i, fallback := range fallbacks {
+		if i >= len(ids) || fallback == "" || fallback == ids[i] {
+			continue
+		}
+		if id, ok := guids[fallback]; ok && id != ids[i] {
+			shared[fallback] = true
+		}
+		guids[fallback] = ids[i]
+	}
+	for fallback := range shared {
+		delete(guids, fallback)
+	}
+	return guids
+}
+
+// guidsChanged reports whether any of the items has a GUID
+// whose Identity differs from the Identity recorded for its
+// FallbackIdentity
+func guidsChanged(guids map[string]string, items []*Item) bool {
+	for _, item := range items {
+		if item == nil || item.GUID == "" {
+			continue
+		}
+		if id, ok := guids[item.FallbackIdentity()]; ok && id != item.Identity() {
+			return true
+		}
+	}
+	return false
+}
+
+func hashFields(fields ...string) string {
+	h := sha1.New()
+	for i, f := range fields {
+		if i > 0 {
+			h.Write([]byte{0})
+		}
+		h.Write([]byte(f))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
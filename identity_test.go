@@ -0,0 +1,79 @@
+package gofeed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItem_Identity(t *testing.T) {
+	published := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	item := &gofeed.Item{
+		GUID:            "guid",
+		Link:            "http://example.com/post",
+		Enclosures:      []*gofeed.Enclosure{{URL: "http://example.com/post.mp3"}},
+		Title:           "Title",
+		PublishedParsed: &published,
+	}
+	assert.Equal(t, "guid:guid", item.Identity())
+
+	item.GUID = ""
+	assert.Equal(t, "link:http://example.com/post", item.Identity())
+
+	item.Link = ""
+	assert.Equal(t, "enclosure:http://example.com/post.mp3", item.Identity())
+
+	item.Enclosures = nil
+	byDate := item.Identity()
+	assert.Len(t, byDate, len("hash:")+40)
+
+	other := &gofeed.Item{Title: "Title", Published: "Wed, 01 Jan 2020 00:00:00 GMT", PublishedParsed: &published}
+	assert.Equal(t, byDate, other.Identity())
+
+	item.PublishedParsed = nil
+	assert.NotEqual(t, byDate, item.Identity())
+	assert.Equal(t, item.Identity(), (&gofeed.Item{Title: "Title"}).Identity())
+
+	guid := &gofeed.Item{GUID: "http://example.com/post"}
+	link := &gofeed.Item{Link: "http://example.com/post"}
+	assert.NotEqual(t, guid.Identity(), link.Identity())
+
+	diff := gofeed.Diff(&gofeed.Feed{Items: []*gofeed.Item{link}}, &gofeed.Feed{Items: []*gofeed.Item{guid}})
+	assert.Equal(t, []*gofeed.Item{guid}, diff.New)
+	assert.Equal(t, []*gofeed.Item{link}, diff.Removed)
+}
+
+func TestUnstableGUIDs(t *testing.T) {
+	prev := &gofeed.Feed{
+		Items: []*gofeed.Item{
+			{GUID: "a1", Link: "http://example.com/1"},
+			{GUID: "a2", Link: "http://example.com/2"},
+		},
+	}
+	stable := &gofeed.Feed{
+		Items: []*gofeed.Item{
+			{GUID: "a1", Link: "http://example.com/1"},
+			{GUID: "a2", Link: "http://example.com/2"},
+			{GUID: "a3", Link: "http://example.com/3"},
+		},
+	}
+	unstable := &gofeed.Feed{
+		Items: []*gofeed.Item{
+			{GUID: "b1", Link: "http://example.com/1"},
+			{GUID: "b2", Link: "http://example.com/2"},
+		},
+	}
+
+	assert.False(t, gofeed.UnstableGUIDs(prev, stable))
+	assert.True(t, gofeed.UnstableGUIDs(prev, unstable))
+	assert.False(t, gofeed.UnstableGUIDs(nil, unstable))
+
+	untitled := &gofeed.Feed{Items: []*gofeed.Item{{GUID: "a1"}, {GUID: "a2"}}}
+	assert.False(t, gofeed.UnstableGUIDs(untitled, &gofeed.Feed{Items: []*gofeed.Item{{GUID: "a3"}, {GUID: "a1"}}}))
+
+	diff := gofeed.Diff(prev, unstable)
+	assert.False(t, diff.HasChanges())
+}
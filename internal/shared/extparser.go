@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/internal/namespaces"
 	xpp "github.com/mmcdole/goxpp"
 )
 
@@ -49,13 +50,29 @@ func parseExtensionElement(p *xpp.XMLPullParser) (e ext.Extension, err error) {
 	}
 
 	e.Name = p.Name
+	e.Namespace = namespaceURI(p.Space, p)
+	e.Prefix = prefixForNamespace(p.Space, p)
 	e.Children = map[string][]ext.Extension{}
 	e.Attrs = map[string]string{}
 
 	for _, attr := range p.Attrs {
-		// TODO: Alright that we are stripping
-		// namespace information from attributes ?
 		e.Attrs[attr.Name.Local] = attr.Value
+
+		// Namespace declarations are written again from
+		// the namespaces of the elements when encoding.
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+
+		a := ext.Attr{Name: attr.Name.Local, Value: attr.Value}
+		if attr.Name.Space == xmlNamespace {
+			a.Namespace = xmlNamespace
+			a.Prefix = "xml"
+		} else if attr.Name.Space != "" {
+			a.Namespace = namespaceURI(attr.Name.Space, p)
+			a.Prefix = prefixForNamespace(attr.Name.Space, p)
+		}
+		e.Attributes = append(e.Attributes, a)
 	}
 
 	for {
@@ -78,13 +95,20 @@ func parseExtensionElement(p *xpp.XMLPullParser) (e ext.Extension, err error) {
 				e.Children[child.Name] = []ext.Extension{}
 			}
 
+			e.Content = append(e.Content, ext.Node{Child: child.Name, Index: len(e.Children[child.Name])})
 			e.Children[child.Name] = append(e.Children[child.Name], child)
 		} else if tok == xpp.Text {
 			e.Value += p.Text
+			if n := len(e.Content); n > 0 && e.Content[n-1].Child == "" {
+				e.Content[n-1].Text += p.Text
+			} else {
+				e.Content = append(e.Content, ext.Node{Text: p.Text})
+			}
 		}
 	}
 
 	e.Value = strings.TrimSpace(e.Value)
+	e.Content = trimContent(e.Content)
 
 	if err = p.Expect(xpp.EndTag, e.Name); err != nil {
 		return e, err
@@ -93,12 +117,68 @@ func parseExtensionElement(p *xpp.XMLPullParser) (e ext.Extension, err error) {
 	return e, nil
 }
 
+// xmlNamespace is the namespace bound to the xml prefix
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// trimContent drops the whitespace between the children of an
+// element which has no other text, since it is only indentation.
+// The content of an element without children is left out as it
+// is the same as the element value.
+func trimContent(content []ext.Node) []ext.Node {
+	hasChildren := false
+	for _, node := range content {
+		if node.Child != "" {
+			hasChildren = true
+		}
+	}
+	if !hasChildren {
+		return nil
+	}
+
+	for _, node := range content {
+		if node.Child == "" && strings.TrimSpace(node.Text) != "" {
+			return content
+		}
+	}
+
+	trimmed := []ext.Node{}
+	for _, node := range content {
+		if node.Child != "" {
+			trimmed = append(trimmed, node)
+		}
+	}
+	return trimmed
+}
+
+// namespaceURI returns the namespace URI of an element or
+// attribute, or an empty string if its prefix was never bound
+// to a namespace, in which case Go's xml.Decoder.Token() leaves
+// the prefix itself as the space.
+func namespaceURI(space string, p *xpp.XMLPullParser) string {
+	if _, ok := p.Spaces[space]; ok {
+		return space
+	}
+	if _, ok := namespaces.Canonical[space]; ok {
+		return space
+	}
+	if _, ok := ext.RegisteredPrefix(space); ok {
+		return space
+	}
+	return ""
+}
+
 func prefixForNamespace(space string, p *xpp.XMLPullParser) string {
-	// First we check if the global namespace map
+	// Namespaces registered by the application take
+	// precedence over everything else.
+	if prefix, ok := ext.RegisteredPrefix(space); ok {
+		return prefix
+	}
+
+	// Next we check if the global namespace map
 	// contains an entry for this namespace/prefix.
 	// This way we can use the canonical prefix for this
 	// ns instead of the one defined in the feed.
-	if prefix, ok := canonicalNamespaces[space]; ok {
+	if prefix, ok := namespaces.Canonical[space]; ok {
 		return prefix
 	}
 
@@ -114,63 +194,20 @@ func prefixForNamespace(space string, p *xpp.XMLPullParser) string {
 	return space
 }
 
-// Namespaces taken from github.com/kurtmckee/feedparser
-// These are used for determining canonical name space prefixes
-// for many of the popular RSS/Atom extensions.
-//
-// These canonical prefixes override any prefixes used in the feed itself.
-var canonicalNamespaces = map[string]string{
-	"http://webns.net/mvcb/":                                         "admin",
-	"http://purl.org/rss/1.0/modules/aggregation/":                   "ag",
-	"http://purl.org/rss/1.0/modules/annotate/":                      "annotate",
-	"http://media.tangent.org/rss/1.0/":                              "audio",
-	"http://backend.userland.com/blogChannelModule":                  "blogChannel",
-	"http://creativecommons.org/ns#license":                          "cc",
-	"http://web.resource.org/cc/":                                    "cc",
-	"http://cyber.law.harvard.edu/rss/creativeCommonsRssModule.html": "creativeCommons",
-	"http://backend.userland.com/creativeCommonsRssModule":           "creativeCommons",
-	"http://purl.org/rss/1.0/modules/company":                        "co",
-	"http://purl.org/rss/1.0/modules/content/":                       "content",
-	"http://my.theinfo.org/changed/1.0/rss/":                         "cp",
-	"http://purl.org/dc/elements/1.1/":                               "dc",
-	"http://purl.org/dc/terms/":                                      "dcterms",
-	"http://purl.org/rss/1.0/modules/email/":                         "email",
-	"http://purl.org/rss/1.0/modules/event/":                         "ev",
-	"http://rssnamespace.org/feedburner/ext/1.0":                     "feedburner",
-	"http://freshmeat.net/rss/fm/":                                   "fm",
-	"http://xmlns.com/foaf/0.1/":                                     "foaf",
-	"http://www.w3.org/2003/01/geo/wgs84_pos#":                       "geo",
-	"http://www.georss.org/georss":                                   "georss",
-	"http://www.opengis.net/gml":                                     "gml",
-	"http://postneo.com/icbm/":                                       "icbm",
-	"http://purl.org/rss/1.0/modules/image/":                         "image",
-	"http://www.itunes.com/DTDs/PodCast-1.0.dtd":                     "itunes",
-	"http://example.com/DTDs/PodCast-1.0.dtd":                        "itunes",
-	"http://purl.org/rss/1.0/modules/link/":                          "l",
-	"http://search.yahoo.com/mrss":                                   "media",
-	"http://search.yahoo.com/mrss/":                                  "media",
-	"http://madskills.com/public/xml/rss/module/pingback/":           "pingback",
-	"http://prismstandard.org/namespaces/1.2/basic/":                 "prism",
-	"http://www.w3.org/1999/02/22-rdf-syntax-ns#":                    "rdf",
-	"http://www.w3.org/2000/01/rdf-schema#":                          "rdfs",
-	"http://purl.org/rss/1.0/modules/reference/":                     "ref",
-	"http://purl.org/rss/1.0/modules/richequiv/":                     "reqv",
-	"http://purl.org/rss/1.0/modules/search/":                        "search",
-	"http://purl.org/rss/1.0/modules/slash/":                         "slash",
-	"http://schemas.xmlsoap.org/soap/envelope/":                      "soap",
-	"http://purl.org/rss/1.0/modules/servicestatus/":                 "ss",
-	"http://hacks.benhammersley.com/rss/streaming/":                  "str",
-	"http://purl.org/rss/1.0/modules/subscription/":                  "sub",
-	"http://purl.org/rss/1.0/modules/syndication/":                   "sy",
-	"http://schemas.pocketsoap.com/rss/myDescModule/":                "szf",
-	"http://purl.org/rss/1.0/modules/taxonomy/":                      "taxo",
-	"http://purl.org/rss/1.0/modules/threading/":                     "thr",
-	"http://purl.org/rss/1.0/modules/textinput/":                     "ti",
-	"http://madskills.com/public/xml/rss/module/trackback/":          "trackback",
-	"http://wellformedweb.org/commentAPI/":                           "wfw",
-	"http://purl.org/rss/1.0/modules/wiki/":                          "wiki",
-	"http://www.w3.org/1999/xhtml":                                   "xhtml",
-	"http://www.w3.org/1999/xlink":                                   "xlink",
-	"http://www.w3.org/XML/1998/namespace":                           "xml",
-	"http://podlove.org/simple-chapters":                             "psc",
+// NamespaceForPrefix returns the namespace URI for one of
+// the canonical or registered extension prefixes, or an
+// empty string if the prefix is not known.
+func NamespaceForPrefix(prefix string) string {
+	if space, ok := ext.RegisteredNamespace(prefix); ok {
+		return space
+	}
+	if space, ok := namespaces.Preferred[prefix]; ok {
+		return space
+	}
+	for space, p := range namespaces.Canonical {
+		if p == prefix {
+			return space
+		}
+	}
+	return ""
 }
@@ -0,0 +1,244 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// ParseExtensions converts the underscore prefixed objects
+// of a JSON feed or item into the generic extension map.  The
+// key without its underscore is used as the prefix (_itunes
+// becomes itunes), and each member of the object becomes an
+// extension element named after its key.  Objects become the
+// children of their element, each value of an array becomes an
+// element of its own, and other values are stored as the element
+// value.  An extension which is not an object is stored as a
+// single element named after the prefix.
+func ParseExtensions(raw map[string]json.RawMessage) ext.Extensions {
+	extensions := ext.Extensions{}
+	for key, data := range raw {
+		prefix := strings.TrimPrefix(key, "_")
+		if prefix == "" || prefix == key {
+			continue
+		}
+
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			continue
+		}
+
+		if object, ok := value.(map[string]interface{}); ok {
+			extensions[prefix] = jsonChildren(prefix, object)
+		} else {
+			elements := jsonElements(prefix, prefix, value)
+			for i := range elements {
+				elements[i].JSONRoot = true
+			}
+			extensions[prefix] = map[string][]ext.Extension{prefix: elements}
+		}
+	}
+
+	if len(extensions) == 0 {
+		return nil
+	}
+	return extensions
+}
+
+// ExtensionElements returns the extensions of the feed as
+// generic extension elements (see ParseExtensions)
+func (f *Feed) ExtensionElements() ext.Extensions {
+	return ParseExtensions(f.Extensions)
+}
+
+// Query evaluates an extension path query (see ext.Extensions.Query)
+// against the extensions of the feed, such as "itunes:owner/email".
+func (f *Feed) Query(path string) (ext.Matches, error) {
+	return f.ExtensionElements().Query(path)
+}
+
+// ExtensionElements returns the extensions of the item as
+// generic extension elements (see ParseExtensions)
+func (i *Item) ExtensionElements() ext.Extensions {
+	return ParseExtensions(i.Extensions)
+}
+
+// Query evaluates an extension path query (see ext.Extensions.Query)
+// against the extensions of the item.
+func (i *Item) Query(path string) (ext.Matches, error) {
+	return i.ExtensionElements().Query(path)
+}
+
+func jsonChildren(prefix string, object map[string]interface{}) map[string][]ext.Extension {
+	children := map[string][]ext.Extension{}
+	for name, value := range object {
+		if elements := jsonElements(prefix, name, value); len(elements) > 0 {
+			children[name] = elements
+		}
+	}
+	return children
+}
+
+func jsonElements(prefix, name string, value interface{}) []ext.Extension {
+	if array, ok := value.([]interface{}); ok {
+		elements := []ext.Extension{}
+		for _, v := range array {
+			if _, nested := v.([]interface{}); nested {
+				elements = append(elements, ext.Extension{
+					Name:      name,
+					Prefix:    prefix,
+					Attrs:     map[string]string{},
+					Children:  map[string][]ext.Extension{name: jsonElements(prefix, name, v)},
+					JSONType:  "array",
+					JSONArray: true,
+				})
+				continue
+			}
+			for _, e := range jsonElements(prefix, name, v) {
+				e.JSONArray = true
+				elements = append(elements, e)
+			}
+		}
+		return elements
+	}
+
+	e := ext.Extension{
+		Name:     name,
+		Prefix:   prefix,
+		Attrs:    map[string]string{},
+		Children: map[string][]ext.Extension{},
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		e.Children = jsonChildren(prefix, v)
+		e.JSONType = "object"
+	case string:
+		e.Value = v
+	case json.Number:
+		e.Value = v.String()
+		e.JSONType = "number"
+	case bool:
+		if v {
+			e.Value = "true"
+		} else {
+			e.Value = "false"
+		}
+		e.JSONType = "boolean"
+	case nil:
+		e.JSONType = "null"
+	}
+	return []ext.Extension{e}
+}
+
+// EncodeExtensions converts the generic extension map into
+// underscore prefixed JSON objects, reversing ParseExtensions.
+// Elements with children or attributes become objects whose
+// members are the attributes and the children, with any text
+// stored under "value".  Repeated elements become arrays.  Values
+// are written as strings unless the element records that it was
+// parsed from a JSON number, boolean, null, object or array.
+// Extensions which ParseExtensions parsed from a value other
+// than an object are written back as that value.
+func EncodeExtensions(extensions ext.Extensions) map[string]json.RawMessage {
+	if len(extensions) == 0 {
+		return nil
+	}
+
+	raw := map[string]json.RawMessage{}
+	for prefix, elements := range extensions {
+		var value interface{}
+		if matches, ok := elements[prefix]; ok && len(elements) == 1 && jsonRoot(matches) {
+			value = jsonValue(matches)
+		} else {
+			value = jsonObject(ext.Extension{Children: elements, JSONType: "object"})
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		raw["_"+prefix] = data
+	}
+	return raw
+}
+
+// jsonRoot reports whether the elements hold the value of an
+// extension which is not an object
+func jsonRoot(elements []ext.Extension) bool {
+	for _, e := range elements {
+		if !e.JSONRoot {
+			return false
+		}
+	}
+	return len(elements) > 0
+}
+
+func jsonValue(elements []ext.Extension) interface{} {
+	if len(elements) == 1 && !elements[0].JSONArray {
+		return jsonObject(elements[0])
+	}
+	values := make([]interface{}, 0, len(elements))
+	for _, e := range elements {
+		values = append(values, jsonObject(e))
+	}
+	return values
+}
+
+func jsonObject(e ext.Extension) interface{} {
+	if e.JSONType == "array" {
+		values := []interface{}{}
+		for _, child := range e.Children[e.Name] {
+			values = append(values, jsonObject(child))
+		}
+		return values
+	}
+
+	object := map[string]interface{}{}
+	if len(e.Attributes) > 0 {
+		for _, attr := range e.Attributes {
+			name := attr.Name
+			if attr.Prefix != "" {
+				name = attr.Prefix + ":" + attr.Name
+			}
+			object[name] = attr.Value
+		}
+	} else {
+		for name, value := range e.Attrs {
+			object[name] = value
+		}
+	}
+	for name, children := range e.Children {
+		if len(children) > 0 {
+			object[name] = jsonValue(children)
+		}
+	}
+
+	if len(object) == 0 && e.JSONType != "object" {
+		return jsonScalar(e)
+	}
+
+	if e.Value != "" {
+		object["value"] = jsonScalar(e)
+	}
+	return object
+}
+
+// jsonScalar returns the value of the element as the JSON type
+// it was parsed from
+func jsonScalar(e ext.Extension) interface{} {
+	switch e.JSONType {
+	case "number":
+		if json.Valid([]byte(e.Value)) {
+			return json.Number(e.Value)
+		}
+	case "boolean":
+		return e.Value == "true"
+	case "null":
+		return nil
+	}
+	return e.Value
+}
@@ -0,0 +1,90 @@
+package json_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+	jsonParser "github.com/mmcdole/gofeed/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const extensionsFeed = `{
+	"version": "https://jsonfeed.org/version/1.1",
+	"title": "Podcast",
+	"_itunes": {"owner": {"name": "Owner", "email": "owner@example.com"}, "explicit": false},
+	"_rating": "adult",
+	"items": [{
+		"id": "1",
+		"content_text": "Episode",
+		"_podcast": {"person": [{"role": "host", "name": "Alice"}, {"role": "guest", "name": "Bob"}]}
+	}]
+}`
+
+func TestFeed_Query(t *testing.T) {
+	fp := jsonParser.Parser{}
+	feed, err := fp.Parse(strings.NewReader(extensionsFeed))
+	require.Nil(t, err)
+
+	matches, err := feed.Query("itunes:owner/email")
+	require.Nil(t, err)
+	assert.Equal(t, "owner@example.com", matches.String())
+
+	matches, err = feed.Query("itunes:explicit")
+	require.Nil(t, err)
+	assert.Equal(t, "false", matches.String())
+
+	matches, err = feed.Query("rating:rating")
+	require.Nil(t, err)
+	assert.Equal(t, "adult", matches.String())
+
+	matches, err = feed.Items[0].Query("podcast:person[2]/name")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Bob"}, matches.Strings())
+
+	matches, err = feed.Items[0].Query("podcast:person/name")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Alice", "Bob"}, matches.Strings())
+
+	matches, err = feed.Items[0].Query("itunes:owner")
+	require.Nil(t, err)
+	assert.Empty(t, matches)
+
+	_, err = feed.Query("itunes:owner[")
+	assert.NotNil(t, err)
+}
+
+func TestExtensions_RoundTrip(t *testing.T) {
+	raw := map[string]json.RawMessage{
+		"_x": json.RawMessage(`{"episode": "12", "explicit": "true", "season": 12, "complete": true, "rating": null, "tags": ["1", 2]}`),
+	}
+
+	encoded := jsonParser.EncodeExtensions(jsonParser.ParseExtensions(raw))
+	assert.JSONEq(t, string(raw["_x"]), string(encoded["_x"]))
+
+	// Arrays of one value, empty objects, nested arrays and
+	// members named after the prefix keep their shape
+	for _, value := range []string{
+		`{"tags": ["a"]}`,
+		`[1]`,
+		`[[1], [2, 3]]`,
+		`{"rating": "adult"}`,
+		`{"rating": ["adult"]}`,
+		`"adult"`,
+		`{}`,
+		`{"o": {}}`,
+		`{"o": [{}]}`,
+	} {
+		raw := map[string]json.RawMessage{"_rating": json.RawMessage(value)}
+		encoded := jsonParser.EncodeExtensions(jsonParser.ParseExtensions(raw))
+		assert.JSONEq(t, value, string(encoded["_rating"]), value)
+	}
+
+	// Values parsed from XML are always strings
+	encoded = jsonParser.EncodeExtensions(ext.Extensions{
+		"itunes": {"episode": {{Name: "episode", Value: "12"}}},
+	})
+	assert.JSONEq(t, `{"episode": "12"}`, string(encoded["_itunes"]))
+}
@@ -1,9 +1,22 @@
 package json
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
 
-// Feed describes the structure for JSON Feed v1.0
-// https://www.jsonfeed.org/version/1/
+// JSON Feed version URLs
+const (
+	Version10 = "https://jsonfeed.org/version/1"
+	Version11 = "https://jsonfeed.org/version/1.1"
+)
+
+// Feed describes the structure for JSON Feed v1.0 and v1.1
+// https://www.jsonfeed.org/version/1.1/
 type Feed struct {
 	Version     string  `json:"version"`                 // version (required, string) is the URL of the version of the format the feed uses
 	Title       string  `json:"title,omitempty"`         // title (required, string) is the name of the feed
@@ -17,12 +30,123 @@ type Feed struct {
 	Author      *Author `json:"author,omitempty"`        // author (optional, object) specifies the feed author. The author object has several members. These are all optional — but if you provide an author object, then at least one is required:
 	Expired     bool    `json:"expired,omitempty"`       // expired (optional, boolean) says whether or not the feed is finished — that is, whether or not it will ever update again.
 	Items       []*Item `json:"items"`                   // items is an array, and is required
-	// TODO Hubs // hubs (very optional, array of objects) describes endpoints that can be used to subscribe to real-time notifications from the publisher of this feed. Each object has a type and url, both of which are required. See the section “Subscribing to Real-time Notifications” below for details.
-	// TODO Extensions
+	Hubs        []*Hub  `json:"hubs,omitempty"`          // hubs (very optional, array of objects) describes endpoints that can be used to subscribe to real-time notifications from the publisher of this feed. Each object has a type and url, both of which are required. See the section “Subscribing to Real-time Notifications” below for details.
 
 	// Version 1.1
 	Authors  []*Author `json:"authors,omitempty"`
 	Language string    `json:"language,omitempty"`
+
+	// Extensions holds the raw value of every top-level key
+	// starting with an underscore (e.g. _itunes)
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the feed with the version URL matching
+// its version, an empty items array rather than null and the
+// author fields appropriate for that version.  Extensions are
+// written back as top-level keys.
+func (f Feed) MarshalJSON() ([]byte, error) {
+	type feed Feed
+	out := feed(f)
+	out.Version = f.VersionURL()
+
+	if out.Version == Version10 {
+		if out.Author == nil && len(out.Authors) > 0 {
+			out.Author = out.Authors[0]
+		}
+		out.Authors = nil
+		out.Language = ""
+	} else if len(out.Authors) == 0 && out.Author != nil {
+		out.Authors = []*Author{out.Author}
+	}
+
+	out.Items = make([]*Item, 0, len(f.Items))
+	for _, item := range f.Items {
+		if item == nil {
+			continue
+		}
+		i := *item
+		if out.Version == Version10 {
+			if i.Author == nil && len(i.Authors) > 0 {
+				i.Author = i.Authors[0]
+			}
+			i.Authors = nil
+			i.Language = ""
+		} else if len(i.Authors) == 0 && i.Author != nil {
+			i.Authors = []*Author{i.Author}
+		}
+		out.Items = append(out.Items, &i)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, f.Extensions)
+}
+
+// Marshal validates the feed and returns it serialized
+// as a JSON Feed document
+func (f Feed) Marshal() ([]byte, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(f)
+}
+
+// MarshalIndent validates the feed and returns it serialized
+// as an indented JSON Feed document
+func (f Feed) MarshalIndent(prefix, indent string) ([]byte, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(f, prefix, indent)
+}
+
+// VersionURL returns the version URL the feed should be written
+// with.  Short versions such as "1.0" are expanded to their URL.
+// If no version is set Version11 is returned.
+func (f Feed) VersionURL() string {
+	v := strings.TrimSuffix(strings.TrimSpace(f.Version), "/")
+	switch {
+	case v == "1" || v == "1.0" || strings.HasSuffix(v, "/version/1"):
+		return Version10
+	case v == "1.1" || strings.HasSuffix(v, "/version/1.1"):
+		return Version11
+	case v == "":
+		return Version11
+	}
+	return f.Version
+}
+
+// Validate checks that the feed contains every field that the
+// JSON Feed specification requires.
+func (f Feed) Validate() error {
+	if f.Title == "" {
+		return errors.New("json feed: title is required")
+	}
+	if err := validateAuthor(f.Author, "json feed: author"); err != nil {
+		return err
+	}
+	for i, a := range f.Authors {
+		if err := validateAuthor(a, fmt.Sprintf("json feed: authors[%d]", i)); err != nil {
+			return err
+		}
+	}
+	for i, h := range f.Hubs {
+		if h != nil && (h.Type == "" || h.URL == "") {
+			return fmt.Errorf("json feed: hubs[%d]: type and url are required", i)
+		}
+	}
+	for i, item := range f.Items {
+		if item == nil {
+			continue
+		}
+		if err := item.validate(i); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (f Feed) String() string {
@@ -45,13 +169,51 @@ type Item struct {
 	DateModified  string  `json:"date_modified,omitempty"`  // date_modified (optional, string) specifies the modification date in RFC 3339 format.
 	Author        *Author `json:"author,omitempty"`         // author (optional, object) has the same structure as the top-level author. If not specified in an item, then the top-level author, if present, is the author of the item.
 
-	Tags        []string       `json:"tags,omitempty"`        // tags (optional, array of strings) can have any plain text values you want. Tags tend to be just one word, but they may be anything.
-	Attachments *[]Attachments `json:"attachments,omitempty"` // attachments (optional, array) lists related resources. Podcasts, for instance, would include an attachment that’s an audio or video file. An individual item may have one or more attachments.
-	// TODO Extensions
+	Tags        []string      `json:"tags,omitempty"`        // tags (optional, array of strings) can have any plain text values you want. Tags tend to be just one word, but they may be anything.
+	Attachments []Attachments `json:"attachments,omitempty"` // attachments (optional, array) lists related resources. Podcasts, for instance, would include an attachment that’s an audio or video file. An individual item may have one or more attachments.
 
 	// Version 1.1
 	Authors  []*Author `json:"authors,omitempty"`
 	Language string    `json:"language,omitempty"`
+
+	// Extensions holds the raw value of every item key
+	// starting with an underscore (e.g. _itunes)
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the item, writing its
+// extensions back as keys of the item
+func (i Item) MarshalJSON() ([]byte, error) {
+	type item Item
+	data, err := json.Marshal(item(i))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, i.Extensions)
+}
+
+func (i Item) validate(index int) error {
+	path := fmt.Sprintf("json feed: items[%d]", index)
+	if i.ID == "" {
+		return fmt.Errorf("%s: id is required", path)
+	}
+	if i.ContentHTML == "" && i.ContentText == "" {
+		return fmt.Errorf("%s: content_html or content_text is required", path)
+	}
+	if err := validateAuthor(i.Author, path+": author"); err != nil {
+		return err
+	}
+	for k, a := range i.Authors {
+		if err := validateAuthor(a, fmt.Sprintf("%s: authors[%d]", path, k)); err != nil {
+			return err
+		}
+	}
+	for k, a := range i.Attachments {
+		if a.URL == "" || a.MimeType == "" {
+			return fmt.Errorf("%s: attachments[%d]: url and mime_type are required", path, k)
+		}
+	}
+	return nil
 }
 
 // Author defines the feed author structure. The author object has several members. These are all optional — but if you provide an author object, then at least one is required:
@@ -61,6 +223,12 @@ type Author struct {
 	Avatar string `json:"avatar,omitempty"` // avatar (optional, string) is the URL for an image for the author. It should be square and relatively large — such as 512 x 512
 }
 
+// Hub defines an endpoint that can be used to subscribe to real-time notifications from the publisher of the feed
+type Hub struct {
+	Type string `json:"type"` // type (required, string) is the protocol used to talk with the hub, such as “WebSub” or “rssCloud.”
+	URL  string `json:"url"`  // url (required, string) is the URL of the hub.
+}
+
 // Attachments defines the structure for related sources. Podcasts, for instance, would include an attachment that’s an audio or video file
 type Attachments struct {
 	URL               string `json:"url,omitempty"`                 // url (required, string) specifies the location of the attachment.
@@ -69,3 +237,44 @@ type Attachments struct {
 	SizeInBytes       int64  `json:"size_in_bytes,omitempty"`       // size_in_bytes (optional, number) specifies how large the file is.
 	DurationInSeconds int64  `json:"duration_in_seconds,omitempty"` // duration_in_seconds (optional, number) specifies how long it takes to listen to or watch, when played at normal speed.
 }
+
+func validateAuthor(a *Author, path string) error {
+	if a != nil && a.Name == "" && a.URL == "" && a.Avatar == "" {
+		return fmt.Errorf("%s: at least one of name, url or avatar is required", path)
+	}
+	return nil
+}
+
+// marshalExtensions appends the extensions to the end of
+// the encoded json object
+func marshalExtensions(data []byte, extensions map[string]json.RawMessage) ([]byte, error) {
+	keys := make([]string, 0, len(extensions))
+	for k := range extensions {
+		if strings.HasPrefix(k, "_") {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return data, nil
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(bytes.TrimSuffix(bytes.TrimSpace(data), []byte("}")))
+	empty := buf.Len() == 1
+	for _, k := range keys {
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(extensions[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,98 @@
+package json_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	jsonParser "github.com/mmcdole/gofeed/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed_Marshal_RoundTrip(t *testing.T) {
+	for _, name := range []string{"version_json_10", "version_json_11"} {
+		f, _ := ioutil.ReadFile("../testdata/parser/json/" + name + ".json")
+
+		fp := &jsonParser.Parser{}
+		expected, err := fp.Parse(bytes.NewReader(f))
+		require.Nil(t, err)
+
+		output, err := expected.MarshalIndent("", "  ")
+		require.Nil(t, err, name)
+
+		actual, err := fp.Parse(bytes.NewReader(output))
+		require.Nil(t, err, name)
+		assert.Equal(t, expected.VersionURL(), actual.Version, name)
+		assert.Equal(t, expected.Items[0].Attachments, actual.Items[0].Attachments, name)
+
+		remarshalled, err := actual.MarshalIndent("", "  ")
+		require.Nil(t, err, name)
+		assert.Equal(t, string(output), string(remarshalled), name)
+	}
+}
+
+func TestFeed_Marshal_Empty(t *testing.T) {
+	feed := jsonParser.Feed{Title: "title"}
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	assert.Equal(t, `{"version":"https://jsonfeed.org/version/1.1","title":"title","items":[]}`, string(output))
+}
+
+func TestFeed_Marshal_Authors(t *testing.T) {
+	author := &jsonParser.Author{Name: "name"}
+
+	feed := jsonParser.Feed{Version: "1.1", Title: "title", Author: author}
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	assert.Contains(t, string(output), `"authors":[{"name":"name"}]`)
+
+	feed = jsonParser.Feed{Version: "1.0", Title: "title", Authors: []*jsonParser.Author{author}, Language: "en"}
+	output, err = feed.Marshal()
+	require.Nil(t, err)
+	assert.Contains(t, string(output), `"version":"https://jsonfeed.org/version/1"`)
+	assert.Contains(t, string(output), `"author":{"name":"name"}`)
+	assert.NotContains(t, string(output), `"authors"`)
+	assert.NotContains(t, string(output), `"language"`)
+}
+
+func TestFeed_Marshal_Extensions(t *testing.T) {
+	feedData := `{"version":"https://jsonfeed.org/version/1.1","title":"title",
+"_microblog":{"about":"https://micro.blog/about/"},
+"items":[{"id":"1","content_text":"text","_itunes":{"episode":1}}]}`
+
+	fp := &jsonParser.Parser{}
+	feed, err := fp.Parse(strings.NewReader(feedData))
+	require.Nil(t, err)
+	assert.Equal(t, json.RawMessage(`{"about":"https://micro.blog/about/"}`), feed.Extensions["_microblog"])
+	assert.Equal(t, json.RawMessage(`{"episode":1}`), feed.Items[0].Extensions["_itunes"])
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	assert.Contains(t, string(output), `"items":[{"id":"1","content_text":"text","_itunes":{"episode":1}}],"_microblog":{"about":"https://micro.blog/about/"}}`)
+}
+
+func TestFeed_Validate(t *testing.T) {
+	var tests = []struct {
+		feed jsonParser.Feed
+		err  string
+	}{
+		{jsonParser.Feed{}, "title is required"},
+		{jsonParser.Feed{Title: "t", Author: &jsonParser.Author{}}, "author: at least one of"},
+		{jsonParser.Feed{Title: "t", Hubs: []*jsonParser.Hub{{Type: "WebSub"}}}, "hubs[0]: type and url are required"},
+		{jsonParser.Feed{Title: "t", Items: []*jsonParser.Item{{ContentText: "c"}}}, "items[0]: id is required"},
+		{jsonParser.Feed{Title: "t", Items: []*jsonParser.Item{{ID: "1"}}}, "items[0]: content_html or content_text is required"},
+		{jsonParser.Feed{Title: "t", Items: []*jsonParser.Item{{ID: "1", ContentText: "c", Attachments: []jsonParser.Attachments{{URL: "u"}}}}}, "attachments[0]: url and mime_type are required"},
+	}
+
+	for _, test := range tests {
+		err := test.feed.Validate()
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+		_, err = test.feed.Marshal()
+		assert.NotNil(t, err)
+	}
+}
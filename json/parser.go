@@ -2,7 +2,9 @@ package json
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
+	"strings"
 
 	jsoniter "github.com/json-iterator/go"
 )
@@ -25,5 +27,46 @@ func (ap *Parser) Parse(feed io.Reader) (*Feed, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	err = ap.parseExtensions(buffer.Bytes(), jsonFeed)
+	if err != nil {
+		return nil, err
+	}
 	return jsonFeed, err
 }
+
+// parseExtensions captures the underscore-prefixed
+// extension objects of the feed and its items
+func (ap *Parser) parseExtensions(data []byte, feed *Feed) error {
+	var raw struct {
+		Items []map[string]json.RawMessage `json:"items"`
+	}
+	if err := j.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := j.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	feed.Extensions = extensionFields(fields)
+
+	for i, item := range raw.Items {
+		if i < len(feed.Items) && feed.Items[i] != nil {
+			feed.Items[i].Extensions = extensionFields(item)
+		}
+	}
+	return nil
+}
+
+func extensionFields(fields map[string]json.RawMessage) (extensions map[string]json.RawMessage) {
+	for k, v := range fields {
+		if strings.HasPrefix(k, "_") {
+			if extensions == nil {
+				extensions = map[string]json.RawMessage{}
+			}
+			extensions[k] = v
+		}
+	}
+	return
+}
@@ -108,11 +108,11 @@ func TestParser_ParseInvalidAndStruct(t *testing.T) {
 	assert.Equal(t, "https://sample-feed-author.com/me.png", actual.Items[0].Author.Avatar)
 	assert.Equal(t, "tag1", actual.Items[0].Tags[0])
 	assert.Equal(t, "tag2", actual.Items[0].Tags[1])
-	assert.Equal(t, "https://sample-json-feed.com/attachment", (*actual.Items[0].Attachments)[0].URL)
-	assert.Equal(t, "audio/mpeg", (*actual.Items[0].Attachments)[0].MimeType)
-	assert.Equal(t, "title", (*actual.Items[0].Attachments)[0].Title)
-	assert.Equal(t, int64(100), (*actual.Items[0].Attachments)[0].SizeInBytes)
-	assert.Equal(t, int64(100), (*actual.Items[0].Attachments)[0].DurationInSeconds)
+	assert.Equal(t, "https://sample-json-feed.com/attachment", actual.Items[0].Attachments[0].URL)
+	assert.Equal(t, "audio/mpeg", actual.Items[0].Attachments[0].MimeType)
+	assert.Equal(t, "title", actual.Items[0].Attachments[0].Title)
+	assert.Equal(t, int64(100), actual.Items[0].Attachments[0].SizeInBytes)
+	assert.Equal(t, int64(100), actual.Items[0].Attachments[0].DurationInSeconds)
 
 	assert.Contains(t, actual.String(), "https://sample-json-feed.com/attachment")
 }
@@ -0,0 +1,108 @@
+package gofeed
+
+import (
+	"net/url"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// License describes the terms under which a given Item may
+// be reused.  URL links to the license itself, ID is an SPDX
+// style identifier (e.g. "CC-BY-SA-4.0") when the URL is that
+// of a well known license, and Text is a free form rights
+// statement such as the Atom rights element.
+type License struct {
+	URL  string `json:"url,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// newLicense returns the license with the given URL and
+// rights statement, or nil if both are empty.
+func newLicense(licenseURL, text string) *License {
+	licenseURL = strings.TrimSpace(licenseURL)
+	text = strings.TrimSpace(text)
+	if licenseURL == "" && text == "" {
+		return nil
+	}
+	return &License{URL: licenseURL, ID: licenseID(licenseURL), Text: text}
+}
+
+// inheritLicense returns a copy of the feed level license for
+// an item which has none of its own.
+func inheritLicense(item, feed *License) *License {
+	if item != nil || feed == nil {
+		return item
+	}
+	license := *feed
+	return &license
+}
+
+// parseLicenseURL returns the license URL of the
+// creativeCommons, cc or dcterms extensions.  The cc module
+// of RSS 1.0 links to the license with an rdf:resource
+// attribute.
+func parseLicenseURL(extensions ext.Extensions) string {
+	for _, prefix := range []string{"creativeCommons", "cc", "dcterms"} {
+		matches, ok := extensions[prefix]["license"]
+		if !ok || len(matches) == 0 {
+			continue
+		}
+		if matches[0].Value != "" {
+			return matches[0].Value
+		}
+		if resource := matches[0].Attrs["resource"]; resource != "" {
+			return resource
+		}
+	}
+	return ""
+}
+
+// spdxLicenses maps the paths of licenses which are not
+// Creative Commons licenses to their SPDX identifiers
+var spdxLicenses = map[string]string{
+	"opensource.org/licenses/mit":          "MIT",
+	"opensource.org/licenses/apache-2.0":   "Apache-2.0",
+	"www.apache.org/licenses/license-2.0":  "Apache-2.0",
+	"opensource.org/licenses/bsd-2-clause": "BSD-2-Clause",
+	"opensource.org/licenses/bsd-3-clause": "BSD-3-Clause",
+	"www.gnu.org/licenses/gpl-2.0":         "GPL-2.0",
+	"www.gnu.org/licenses/gpl-3.0":         "GPL-3.0",
+	"www.gnu.org/licenses/fdl-1.3":         "GFDL-1.3",
+}
+
+// licenseID returns the SPDX identifier of a license URL, such
+// as CC-BY-NC-4.0 for http://creativecommons.org/licenses/by-nc/4.0/
+// or CC0-1.0 for the Creative Commons public domain dedication.
+// It returns an empty string for licenses it does not recognize.
+func licenseID(licenseURL string) string {
+	u, err := url.Parse(licenseURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	host := strings.ToLower(u.Host)
+	path := strings.Trim(strings.ToLower(u.Path), "/")
+	path = strings.TrimSuffix(strings.TrimSuffix(path, ".html"), ".txt")
+
+	if host == "creativecommons.org" || host == "www.creativecommons.org" {
+		parts := strings.Split(path, "/")
+		switch {
+		case len(parts) >= 3 && parts[0] == "licenses":
+			id := "CC-" + strings.ToUpper(parts[1]) + "-" + parts[2]
+			if len(parts) >= 4 && !strings.HasPrefix(parts[3], "legalcode") && !strings.HasPrefix(parts[3], "deed") {
+				id += "-" + strings.ToUpper(parts[3])
+			}
+			return id
+		case len(parts) >= 3 && parts[0] == "publicdomain" && parts[1] == "zero":
+			return "CC0-" + parts[2]
+		}
+		return ""
+	}
+
+	if host == "gnu.org" {
+		host = "www.gnu.org"
+	}
+	return spdxLicenses[host+"/"+path]
+}
@@ -0,0 +1,92 @@
+package gofeed_test
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_License_RSS(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<rss version="2.0"
+  xmlns:creativeCommons="http://backend.userland.com/creativeCommonsRssModule"
+  xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+<creativeCommons:license>http://creativecommons.org/licenses/by-nc-sa/2.0/</creativeCommons:license>
+<item>
+<title>Inherited</title>
+</item>
+<item>
+<title>Own license</title>
+<creativeCommons:license>https://creativecommons.org/licenses/by/3.0/de/</creativeCommons:license>
+<dc:rights>Some rights reserved</dc:rights>
+</item>
+</channel>
+</rss>`)
+	require.Nil(t, err)
+	require.Len(t, feed.Items, 2)
+
+	assert.Equal(t, &gofeed.License{
+		URL: "http://creativecommons.org/licenses/by-nc-sa/2.0/",
+		ID:  "CC-BY-NC-SA-2.0",
+	}, feed.Items[0].License)
+	assert.Equal(t, &gofeed.License{
+		URL:  "https://creativecommons.org/licenses/by/3.0/de/",
+		ID:   "CC-BY-3.0-DE",
+		Text: "Some rights reserved",
+	}, feed.Items[1].License)
+}
+
+func TestItem_License_RDF(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<rdf:RDF
+  xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+  xmlns="http://purl.org/rss/1.0/"
+  xmlns:cc="http://web.resource.org/cc/">
+<channel rdf:about="http://example.com/">
+<title>Example</title>
+</channel>
+<item rdf:about="http://example.com/1">
+<title>Item</title>
+<cc:license rdf:resource="http://creativecommons.org/publicdomain/zero/1.0/"/>
+</item>
+</rdf:RDF>`)
+	require.Nil(t, err)
+	require.Len(t, feed.Items, 1)
+	assert.Equal(t, &gofeed.License{
+		URL: "http://creativecommons.org/publicdomain/zero/1.0/",
+		ID:  "CC0-1.0",
+	}, feed.Items[0].License)
+}
+
+func TestItem_License_Atom(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<feed xmlns="http://www.w3.org/2005/Atom">
+<title>Blog</title>
+<rights>Copyright 2021 Example</rights>
+<entry>
+<id>1</id>
+<title>Inherited</title>
+</entry>
+<entry>
+<id>2</id>
+<title>Own license</title>
+<rights>Released under the MIT license</rights>
+<link rel="license" href="https://opensource.org/licenses/MIT"/>
+</entry>
+<entry>
+<id>3</id>
+<title>Unknown license</title>
+<link rel="license" href="https://example.com/license"/>
+</entry>
+</feed>`)
+	require.Nil(t, err)
+	require.Len(t, feed.Items, 3)
+
+	assert.Equal(t, &gofeed.License{Text: "Copyright 2021 Example"}, feed.Items[0].License)
+	assert.Equal(t, &gofeed.License{
+		URL:  "https://opensource.org/licenses/MIT",
+		ID:   "MIT",
+		Text: "Released under the MIT license",
+	}, feed.Items[1].License)
+	assert.Equal(t, &gofeed.License{URL: "https://example.com/license"}, feed.Items[2].License)
+}
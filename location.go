@@ -0,0 +1,209 @@
+package gofeed
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// Kinds of geometry a Location can describe
+const (
+	LocationPoint   = "point"
+	LocationLine    = "line"
+	LocationPolygon = "polygon"
+	LocationBox     = "box"
+)
+
+// Location is the geographic location that a given Item is
+// about.  It is parsed from GeoRSS Simple, GeoRSS GML and the
+// W3C geo and icbm extensions.
+//
+// Points holds one point for a point location, two or more for
+// a line, the closed ring of a polygon, or the lower and upper
+// corners of a box.
+type Location struct {
+	Type        string   `json:"type,omitempty"`
+	Points      []*Point `json:"points,omitempty"`
+	Elevation   float64  `json:"elevation,omitempty"`
+	Radius      float64  `json:"radius,omitempty"`
+	FeatureName string   `json:"featureName,omitempty"`
+}
+
+// Point is a WGS84 latitude and longitude in decimal degrees.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// parseLocation returns the location described by the georss,
+// gml, geo or icbm extensions, in that order of precedence.
+// Coordinates that are missing, not numbers or out of range
+// are ignored and nil is returned if no valid location is found.
+func parseLocation(extensions ext.Extensions) (location *Location) {
+	georss := extensions["georss"]
+	if georss != nil {
+		location = parseGeoRSSSimple(georss)
+		if location == nil {
+			if where, ok := georss["where"]; ok && len(where) > 0 {
+				location = parseGML(where[0].Children)
+			}
+		}
+	}
+
+	if location == nil {
+		location = parseGML(extensions["gml"])
+	}
+
+	if location == nil {
+		if geo := extensions["geo"]; geo != nil {
+			location = parseLatLon(geo, "lat", "long")
+			if location == nil {
+				if points, ok := geo["Point"]; ok && len(points) > 0 {
+					location = parseLatLon(points[0].Children, "lat", "long")
+				}
+			}
+			if location != nil {
+				location.Elevation = parseLocationFloat(geo, "alt")
+			}
+		}
+	}
+
+	if location == nil {
+		location = parseLatLon(extensions["icbm"], "latitude", "longitude")
+	}
+
+	if location != nil && georss != nil {
+		if location.Elevation == 0 {
+			location.Elevation = parseLocationFloat(georss, "elev")
+		}
+		location.Radius = parseLocationFloat(georss, "radius")
+		location.FeatureName = locationText(georss, "featureName")
+	}
+	return
+}
+
+func parseGeoRSSSimple(georss map[string][]ext.Extension) *Location {
+	for _, kind := range []string{LocationPoint, LocationLine, LocationPolygon, LocationBox} {
+		if value := locationText(georss, kind); value != "" {
+			return newLocation(kind, value)
+		}
+	}
+	return nil
+}
+
+// parseGML returns the location of the first gml:Point,
+// gml:LineString, gml:Polygon or gml:Envelope element.
+func parseGML(gml map[string][]ext.Extension) *Location {
+	if gml == nil {
+		return nil
+	}
+
+	if point := firstLocationExtension(gml, "Point"); point != nil {
+		return newLocation(LocationPoint, locationText(point.Children, "pos"))
+	}
+
+	if line := firstLocationExtension(gml, "LineString"); line != nil {
+		return newLocation(LocationLine, locationText(line.Children, "posList"))
+	}
+
+	if polygon := firstLocationExtension(gml, "Polygon"); polygon != nil {
+		if exterior := firstLocationExtension(polygon.Children, "exterior"); exterior != nil {
+			if ring := firstLocationExtension(exterior.Children, "LinearRing"); ring != nil {
+				return newLocation(LocationPolygon, locationText(ring.Children, "posList"))
+			}
+		}
+		return nil
+	}
+
+	if envelope := firstLocationExtension(gml, "Envelope"); envelope != nil {
+		corners := locationText(envelope.Children, "lowerCorner") + " " + locationText(envelope.Children, "upperCorner")
+		return newLocation(LocationBox, corners)
+	}
+
+	return nil
+}
+
+func parseLatLon(extensions map[string][]ext.Extension, lat, lon string) *Location {
+	if extensions == nil {
+		return nil
+	}
+	return newLocation(LocationPoint, locationText(extensions, lat)+" "+locationText(extensions, lon))
+}
+
+// invalidFloat reports whether f is NaN or infinite, which
+// ParseFloat accepts but no coordinate can be
+func invalidFloat(f float64) bool {
+	return math.IsNaN(f) || math.IsInf(f, 0)
+}
+
+// newLocation parses a whitespace separated list of latitude
+// and longitude pairs and checks that they make up a valid
+// geometry of the given kind.
+func newLocation(kind, coordinates string) *Location {
+	fields := strings.Fields(strings.Replace(coordinates, ",", " ", -1))
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return nil
+	}
+
+	points := []*Point{}
+	for i := 0; i < len(fields); i += 2 {
+		lat, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil || invalidFloat(lat) || lat < -90 || lat > 90 {
+			return nil
+		}
+		lon, err := strconv.ParseFloat(fields[i+1], 64)
+		if err != nil || invalidFloat(lon) || lon < -180 || lon > 180 {
+			return nil
+		}
+		points = append(points, &Point{Lat: lat, Lon: lon})
+	}
+
+	switch kind {
+	case LocationPoint:
+		if len(points) != 1 {
+			return nil
+		}
+	case LocationLine:
+		if len(points) < 2 {
+			return nil
+		}
+	case LocationPolygon:
+		first, last := points[0], points[len(points)-1]
+		if len(points) < 4 || first.Lat != last.Lat || first.Lon != last.Lon {
+			return nil
+		}
+	case LocationBox:
+		if len(points) != 2 || points[0].Lat > points[1].Lat {
+			return nil
+		}
+	}
+
+	return &Location{Type: kind, Points: points}
+}
+
+// parseLocationFloat returns the value of an elevation or
+// radius, or zero if it is missing or not a finite number
+func parseLocationFloat(extensions map[string][]ext.Extension, name string) float64 {
+	f, err := strconv.ParseFloat(locationText(extensions, name), 64)
+	if err != nil || invalidFloat(f) {
+		return 0
+	}
+	return f
+}
+
+func locationText(extensions map[string][]ext.Extension, name string) string {
+	if e := firstLocationExtension(extensions, name); e != nil {
+		return strings.TrimSpace(e.Value)
+	}
+	return ""
+}
+
+func firstLocationExtension(extensions map[string][]ext.Extension, name string) *ext.Extension {
+	matches, ok := extensions[name]
+	if !ok || len(matches) == 0 {
+		return nil
+	}
+	return &matches[0]
+}
@@ -0,0 +1,114 @@
+package gofeed_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseItemLocation(t *testing.T, item string) *gofeed.Location {
+	feed, err := gofeed.NewParser().ParseString(fmt.Sprintf(`<rss version="2.0"
+  xmlns:georss="http://www.georss.org/georss"
+  xmlns:gml="http://www.opengis.net/gml"
+  xmlns:geo="http://www.w3.org/2003/01/geo/wgs84_pos#"
+  xmlns:icbm="http://postneo.com/icbm/">
+<channel><item>%s</item></channel>
+</rss>`, item))
+	require.Nil(t, err)
+	require.Len(t, feed.Items, 1)
+	return feed.Items[0].Location
+}
+
+func TestItem_Location(t *testing.T) {
+	tests := []struct {
+		item     string
+		expected *gofeed.Location
+	}{
+		{
+			`<georss:point>45.256 -71.92</georss:point><georss:featureName>Office</georss:featureName><georss:elev>313</georss:elev>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 45.256, Lon: -71.92}}, Elevation: 313, FeatureName: "Office"},
+		},
+		{
+			`<georss:point>45.256,-71.92</georss:point><georss:radius>500</georss:radius>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 45.256, Lon: -71.92}}, Radius: 500},
+		},
+		{
+			`<georss:line>45.256 -110.45 46.46 -109.48 43.84 -109.86</georss:line>`,
+			&gofeed.Location{Type: gofeed.LocationLine, Points: []*gofeed.Point{{Lat: 45.256, Lon: -110.45}, {Lat: 46.46, Lon: -109.48}, {Lat: 43.84, Lon: -109.86}}},
+		},
+		{
+			`<georss:polygon>45.256 -110.45 46.46 -109.48 43.84 -109.86 45.256 -110.45</georss:polygon>`,
+			&gofeed.Location{Type: gofeed.LocationPolygon, Points: []*gofeed.Point{{Lat: 45.256, Lon: -110.45}, {Lat: 46.46, Lon: -109.48}, {Lat: 43.84, Lon: -109.86}, {Lat: 45.256, Lon: -110.45}}},
+		},
+		{
+			`<georss:box>42.943 -71.032 43.039 -69.856</georss:box>`,
+			&gofeed.Location{Type: gofeed.LocationBox, Points: []*gofeed.Point{{Lat: 42.943, Lon: -71.032}, {Lat: 43.039, Lon: -69.856}}},
+		},
+		{
+			`<georss:where><gml:Point><gml:pos>45.256 -71.92</gml:pos></gml:Point></georss:where>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 45.256, Lon: -71.92}}},
+		},
+		{
+			`<georss:where><gml:Polygon><gml:exterior><gml:LinearRing><gml:posList>45.256 -110.45 46.46 -109.48 43.84 -109.86 45.256 -110.45</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon></georss:where>`,
+			&gofeed.Location{Type: gofeed.LocationPolygon, Points: []*gofeed.Point{{Lat: 45.256, Lon: -110.45}, {Lat: 46.46, Lon: -109.48}, {Lat: 43.84, Lon: -109.86}, {Lat: 45.256, Lon: -110.45}}},
+		},
+		{
+			`<georss:where><gml:Envelope><gml:lowerCorner>42.943 -71.032</gml:lowerCorner><gml:upperCorner>43.039 -69.856</gml:upperCorner></gml:Envelope></georss:where>`,
+			&gofeed.Location{Type: gofeed.LocationBox, Points: []*gofeed.Point{{Lat: 42.943, Lon: -71.032}, {Lat: 43.039, Lon: -69.856}}},
+		},
+		{
+			`<geo:lat>26.58</geo:lat><geo:long>-97.83</geo:long><geo:alt>12</geo:alt>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 26.58, Lon: -97.83}}, Elevation: 12},
+		},
+		{
+			`<geo:Point><geo:lat>26.58</geo:lat><geo:long>-97.83</geo:long></geo:Point>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 26.58, Lon: -97.83}}},
+		},
+		{
+			`<icbm:latitude>26.58</icbm:latitude><icbm:longitude>-97.83</icbm:longitude>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 26.58, Lon: -97.83}}},
+		},
+		{
+			// GeoRSS takes precedence over W3C geo
+			`<geo:lat>1</geo:lat><geo:long>2</geo:long><georss:point>3 4</georss:point>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 3, Lon: 4}}},
+		},
+		{
+			`<georss:point>45.256 -71.92</georss:point><georss:elev>NaN</georss:elev><georss:radius>Inf</georss:radius>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 45.256, Lon: -71.92}}},
+		},
+		{
+			`<geo:lat>26.58</geo:lat><geo:long>-97.83</geo:long><geo:alt>-Inf</geo:alt>`,
+			&gofeed.Location{Type: gofeed.LocationPoint, Points: []*gofeed.Point{{Lat: 26.58, Lon: -97.83}}},
+		},
+		{`<georss:point>95 10</georss:point>`, nil},
+		{`<georss:point>45 190</georss:point>`, nil},
+		{`<georss:point>NaN NaN</georss:point>`, nil},
+		{`<georss:point>45 -Inf</georss:point>`, nil},
+		{`<geo:lat>NaN</geo:lat><geo:long>2</geo:long>`, nil},
+		{`<georss:point>north west</georss:point>`, nil},
+		{`<georss:point>45.256</georss:point>`, nil},
+		{`<georss:line>45.256 -110.45</georss:line>`, nil},
+		{`<georss:polygon>45.256 -110.45 46.46 -109.48 43.84 -109.86 44 -110</georss:polygon>`, nil},
+		{`<georss:box>43.039 -69.856 42.943 -71.032</georss:box>`, nil},
+		{`<geo:lat>26.58</geo:lat>`, nil},
+		{`<title>Nowhere</title>`, nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, parseItemLocation(t, test.item), test.item)
+	}
+}
+
+func TestItem_Location_NaNElevation(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`<rss version="2.0" xmlns:georss="http://www.georss.org/georss">
+<channel><item><georss:point>45.256 -71.92</georss:point><georss:elev>NaN</georss:elev></item></channel>
+</rss>`)
+	require.Nil(t, err)
+	require.NotNil(t, feed.Items[0].Location)
+	assert.Equal(t, float64(0), feed.Items[0].Location.Elevation)
+	assert.NotEmpty(t, feed.String())
+}
@@ -0,0 +1,139 @@
+package gofeed
+
+import (
+	"sort"
+	"time"
+)
+
+// MergeOptions configures how Merge combines feeds.
+type MergeOptions struct {
+	Title       string
+	Description string
+	Link        string
+	FeedLink    string
+
+	// MaxItemsPerFeed caps the number of items taken from
+	// each source feed.  Zero means no limit.
+	MaxItemsPerFeed int
+
+	// MaxItems caps the number of items in the merged
+	// feed.  Zero means no limit.
+	MaxItems int
+}
+
+// Merge combines several feeds into a single feed.
+//
+// Items are deduplicated by their Identity, keeping the most
+// recent copy, and sorted from newest to oldest by their
+// published date (or updated date if no published date is
+// available).  Items without any date are placed last.
+// Each item is tagged with the feed it came from in
+// Item.Origin, and in Item.Source unless it already carries
+// the source it was republished from.  The source feeds and
+// their items are not modified.
+func Merge(opts *MergeOptions, feeds ...*Feed) *Feed {
+	if opts == nil {
+		opts = &MergeOptions{}
+	}
+
+	result := &Feed{}
+	result.Title = opts.Title
+	result.Description = opts.Description
+	result.Link = opts.Link
+	result.FeedLink = opts.FeedLink
+	result.Items = []*Item{}
+
+	all := []*Item{}
+	for _, feed := range feeds {
+		if feed == nil {
+			continue
+		}
+
+		items := make([]*Item, 0, len(feed.Items))
+		for _, item := range feed.Items {
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+		sortNewestFirst(items)
+
+		if opts.MaxItemsPerFeed > 0 && len(items) > opts.MaxItemsPerFeed {
+			items = items[:opts.MaxItemsPerFeed]
+		}
+
+		for _, item := range items {
+			merged := *item
+			merged.Origin = &Source{
+				Title:    feed.Title,
+				Link:     feed.Link,
+				FeedLink: feed.FeedLink,
+			}
+			if merged.Source == nil {
+				origin := *merged.Origin
+				merged.Source = &origin
+			}
+			all = append(all, &merged)
+		}
+	}
+
+	sortNewestFirst(all)
+
+	seen := map[string]bool{}
+	for _, item := range all {
+		id := item.Identity()
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result.Items = append(result.Items, item)
+	}
+
+	if opts.MaxItems > 0 && len(result.Items) > opts.MaxItems {
+		result.Items = result.Items[:opts.MaxItems]
+	}
+
+	if len(result.Items) > 0 {
+		if date := itemDate(result.Items[0]); date != nil {
+			updated := *date
+			result.UpdatedParsed = &updated
+			result.Updated = updated.Format(time.RFC3339)
+		}
+	}
+
+	return result
+}
+
+func sortNewestFirst(items []*Item) {
+	sort.SliceStable(items, func(i, k int) bool {
+		a, b := itemDate(items[i]), itemDate(items[k])
+		if a == nil {
+			return false
+		}
+		if b == nil {
+			return true
+		}
+		return a.After(*b)
+	})
+}
+
+// sortOldestFirst is the reverse of sortNewestFirst.  Items
+// without a date come first and keep their order.
+func sortOldestFirst(items []*Item) {
+	sort.SliceStable(items, func(i, k int) bool {
+		a, b := itemDate(items[i]), itemDate(items[k])
+		if b == nil {
+			return false
+		}
+		if a == nil {
+			return true
+		}
+		return a.Before(*b)
+	})
+}
+
+func itemDate(item *Item) *time.Time {
+	if item.PublishedParsed != nil {
+		return item.PublishedParsed
+	}
+	return item.UpdatedParsed
+}
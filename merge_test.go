@@ -0,0 +1,68 @@
+package gofeed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+
+	a := &gofeed.Feed{
+		Title: "A",
+		Link:  "http://a.example.com",
+		Items: []*gofeed.Item{
+			{GUID: "a1", PublishedParsed: day(1)},
+			{GUID: "a2", PublishedParsed: day(3)},
+			{GUID: "a3", PublishedParsed: day(5)},
+			{GUID: "shared", PublishedParsed: day(2)},
+		},
+	}
+	b := &gofeed.Feed{
+		Title: "B",
+		Link:  "http://b.example.com",
+		Items: []*gofeed.Item{
+			{GUID: "b1", PublishedParsed: day(4)},
+			{GUID: "b2", Source: &gofeed.Source{Title: "Original"}},
+			{GUID: "shared", PublishedParsed: day(6)},
+		},
+	}
+
+	merged := gofeed.Merge(&gofeed.MergeOptions{Title: "Planet", MaxItemsPerFeed: 3}, a, b, nil)
+	assert.Equal(t, "Planet", merged.Title)
+
+	ids := []string{}
+	for _, item := range merged.Items {
+		ids = append(ids, item.GUID)
+	}
+	assert.Equal(t, []string{"shared", "a3", "b1", "a2", "b2"}, ids)
+
+	require.NotNil(t, merged.Items[0].Source)
+	assert.Equal(t, "B", merged.Items[0].Source.Title)
+	assert.Equal(t, "http://a.example.com", merged.Items[1].Source.Link)
+	assert.True(t, merged.UpdatedParsed.Equal(*day(6)))
+
+	// Items which carry a source keep it and are still tagged
+	// with the feed they were merged from
+	assert.Equal(t, "b2", merged.Items[4].GUID)
+	assert.Equal(t, "Original", merged.Items[4].Source.Title)
+	require.NotNil(t, merged.Items[4].Origin)
+	assert.Equal(t, "B", merged.Items[4].Origin.Title)
+	assert.Equal(t, "http://b.example.com", merged.Items[4].Origin.Link)
+	assert.Equal(t, "A", merged.Items[1].Origin.Title)
+
+	// The source feeds are left untouched
+	assert.Nil(t, a.Items[0].Source)
+	assert.Nil(t, a.Items[0].Origin)
+	assert.Equal(t, "a1", a.Items[0].GUID)
+
+	merged = gofeed.Merge(&gofeed.MergeOptions{MaxItems: 2}, a, b)
+	assert.Len(t, merged.Items, 2)
+}
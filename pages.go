@@ -0,0 +1,223 @@
+package gofeed
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/mmcdole/gofeed/json"
+)
+
+// DefaultMaxPages is the number of pages followed when
+// PageOptions does not set MaxPages
+const DefaultMaxPages = 100
+
+// ErrPageLimit is returned when a feed has more pages
+// than PageOptions.MaxPages allows
+var ErrPageLimit = errors.New("Feed page limit reached")
+
+// ErrPageLoop is returned when a page links back to a
+// page which was already fetched
+var ErrPageLoop = errors.New("Feed pages form a loop")
+
+// PageOptions configures how the pages of a feed are followed.
+type PageOptions struct {
+	// MaxPages caps the number of pages fetched, including
+	// the first one.  Zero means DefaultMaxPages.
+	MaxPages int
+}
+
+func (opts *PageOptions) maxPages() int {
+	if opts == nil || opts.MaxPages <= 0 {
+		return DefaultMaxPages
+	}
+	return opts.MaxPages
+}
+
+// JSONPageIterator walks the pages of a JSON Feed by
+// following their next_url.  Call Next to fetch each page
+// and Err to find out why the walk stopped:
+//
+//	pages := fp.JSONPages("https://example.com/feed.json", nil)
+//	for pages.Next() {
+//		fmt.Println(pages.URL(), len(pages.Feed().Items))
+//	}
+//	if err := pages.Err(); err != nil {
+//		...
+//	}
+type JSONPageIterator struct {
+	parser   *Parser
+	ctx      context.Context
+	maxPages int
+	next     string
+	seen     map[string]bool
+	url      string
+	json     *json.Feed
+	feed     *Feed
+	err      error
+}
+
+// JSONPages returns an iterator over the pages of the JSON
+// Feed at feedURL
+func (f *Parser) JSONPages(feedURL string, opts *PageOptions) *JSONPageIterator {
+	return f.JSONPagesWithContext(feedURL, opts, context.Background())
+}
+
+// JSONPagesWithContext returns an iterator over the pages of
+// the JSON Feed at feedURL.  Requests could be canceled or
+// timeout via given context
+func (f *Parser) JSONPagesWithContext(feedURL string, opts *PageOptions, ctx context.Context) *JSONPageIterator {
+	return &JSONPageIterator{
+		parser:   f,
+		ctx:      ctx,
+		maxPages: opts.maxPages(),
+		next:     feedURL,
+		seen:     map[string]bool{},
+	}
+}
+
+// Next fetches the next page.  It returns false when the last
+// page has been fetched or an error occurred.
+func (it *JSONPageIterator) Next() bool {
+	if it.err != nil || it.next == "" {
+		return false
+	}
+
+	pageURL, err := it.resolve(it.next)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if it.seen[pageURL] {
+		it.err = ErrPageLoop
+		return false
+	}
+	if len(it.seen) >= it.maxPages {
+		it.err = ErrPageLimit
+		return false
+	}
+	it.seen[pageURL] = true
+
+	jf, feed, err := it.parser.fetchJSONPage(pageURL, it.ctx)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.url = pageURL
+	it.json = jf
+	it.feed = feed
+	it.next = jf.NextURL
+	return true
+}
+
+// continueJSONPages returns an iterator over the pages which
+// follow the JSON Feed page jf, already fetched from pageURL
+func (f *Parser) continueJSONPages(pageURL string, jf *json.Feed, feed *Feed, opts *PageOptions, ctx context.Context) *JSONPageIterator {
+	it := f.JSONPagesWithContext(pageURL, opts, ctx)
+	it.seen[pageURL] = true
+	it.url = pageURL
+	it.json = jf
+	it.feed = feed
+	it.next = jf.NextURL
+	return it
+}
+
+// URL returns the URL of the current page
+func (it *JSONPageIterator) URL() string {
+	return it.url
+}
+
+// Feed returns the current page translated into the
+// universal feed type
+func (it *JSONPageIterator) Feed() *Feed {
+	return it.feed
+}
+
+// JSON returns the current page as it was parsed
+func (it *JSONPageIterator) JSON() *json.Feed {
+	return it.json
+}
+
+// Err returns the error which stopped the iteration, or nil
+// if every page was fetched
+func (it *JSONPageIterator) Err() error {
+	return it.err
+}
+
+// resolve makes next_url absolute against the current page
+// and drops its fragment, so that the same page is not
+// fetched twice under different URLs
+func (it *JSONPageIterator) resolve(next string) (string, error) {
+	u, err := url.Parse(next)
+	if err != nil {
+		return "", err
+	}
+	if it.url != "" {
+		base, err := url.Parse(it.url)
+		if err != nil {
+			return "", err
+		}
+		u = base.ResolveReference(u)
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// ParseJSONPages fetches every page of the JSON Feed at
+// feedURL and merges them into a single feed.
+//
+// The merged feed takes its fields from the first page and
+// the items of all pages in page order, without the items
+// whose Identity already appeared on an earlier page.  If an
+// error occurs after the first page, the merged feed of the
+// pages fetched so far is returned along with the error.
+func (f *Parser) ParseJSONPages(feedURL string, opts *PageOptions) (*Feed, error) {
+	return f.ParseJSONPagesWithContext(feedURL, opts, context.Background())
+}
+
+// ParseJSONPagesWithContext is like ParseJSONPages but the
+// requests could be canceled or timeout via given context
+func (f *Parser) ParseJSONPagesWithContext(feedURL string, opts *PageOptions, ctx context.Context) (*Feed, error) {
+	var result *Feed
+	seen := map[string]bool{}
+
+	pages := f.JSONPagesWithContext(feedURL, opts, ctx)
+	for pages.Next() {
+		page := pages.Feed()
+		if result == nil {
+			merged := *page
+			merged.Items = []*Item{}
+			result = &merged
+		}
+		for _, item := range page.Items {
+			if item == nil {
+				continue
+			}
+			id := item.Identity()
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			result.Items = append(result.Items, item)
+		}
+	}
+
+	return result, pages.Err()
+}
+
+func (f *Parser) fetchJSONPage(pageURL string, ctx context.Context) (jf *json.Feed, feed *Feed, err error) {
+	_, err = f.fetchBody(pageURL, ctx, nil, func(body io.Reader) (err error) {
+		jf, err = f.jp.Parse(body)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	feed, err = f.jsonTrans().Translate(jf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return jf, feed, nil
+}
@@ -0,0 +1,109 @@
+package gofeed_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedServer serves JSON Feed pages by path.  Each page has
+// the items given for it and links to the next path.
+func pagedServer(pages map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/feed+json")
+		fmt.Fprint(w, page)
+	}))
+}
+
+func jsonPage(title, next string, ids ...string) string {
+	items := ""
+	for i, id := range ids {
+		if i > 0 {
+			items += ","
+		}
+		items += fmt.Sprintf(`{"id":%q,"title":%q}`, id, "Item "+id)
+	}
+	return fmt.Sprintf(`{"version":"https://jsonfeed.org/version/1.1","title":%q,"next_url":%q,"items":[%s]}`, title, next, items)
+}
+
+func TestParser_ParseJSONPages(t *testing.T) {
+	server := pagedServer(map[string]string{
+		"/feed.json":        jsonPage("Archive", "/feed.json?page=2", "5", "4"),
+		"/feed.json?page=2": jsonPage("Archive page 2", "feed.json?page=3#items", "4", "3", "2"),
+		"/feed.json?page=3": jsonPage("Archive page 3", "", "1"),
+	})
+	defer server.Close()
+
+	feed, err := gofeed.NewParser().ParseJSONPages(server.URL+"/feed.json", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "Archive", feed.Title)
+
+	ids := []string{}
+	for _, item := range feed.Items {
+		ids = append(ids, item.GUID)
+	}
+	assert.Equal(t, []string{"5", "4", "3", "2", "1"}, ids)
+}
+
+func TestParser_JSONPages(t *testing.T) {
+	server := pagedServer(map[string]string{
+		"/feed.json":        jsonPage("Archive", "/feed.json?page=2", "2"),
+		"/feed.json?page=2": jsonPage("Archive page 2", "", "1"),
+	})
+	defer server.Close()
+
+	pages := gofeed.NewParser().JSONPages(server.URL+"/feed.json", nil)
+	urls := []string{}
+	for pages.Next() {
+		urls = append(urls, pages.URL())
+		assert.Len(t, pages.Feed().Items, 1)
+		assert.Equal(t, pages.Feed().Title, pages.JSON().Title)
+	}
+	assert.Nil(t, pages.Err())
+	assert.Equal(t, []string{server.URL + "/feed.json", server.URL + "/feed.json?page=2"}, urls)
+	assert.False(t, pages.Next())
+}
+
+func TestParser_ParseJSONPages_Errors(t *testing.T) {
+	server := pagedServer(map[string]string{
+		"/loop.json":        jsonPage("Loop", "/loop.json?page=2", "2"),
+		"/loop.json?page=2": jsonPage("Loop page 2", "/loop.json#top", "1"),
+		"/many.json":        jsonPage("Many", "/many.json?page=2", "3"),
+		"/many.json?page=2": jsonPage("Many page 2", "/many.json?page=3", "2"),
+		"/many.json?page=3": jsonPage("Many page 3", "", "1"),
+		"/broken.json":      jsonPage("Broken", "/missing.json", "1"),
+	})
+	defer server.Close()
+	fp := gofeed.NewParser()
+
+	feed, err := fp.ParseJSONPages(server.URL+"/loop.json", nil)
+	assert.Equal(t, gofeed.ErrPageLoop, err)
+	require.NotNil(t, feed)
+	assert.Len(t, feed.Items, 2)
+
+	feed, err = fp.ParseJSONPages(server.URL+"/many.json", &gofeed.PageOptions{MaxPages: 2})
+	assert.Equal(t, gofeed.ErrPageLimit, err)
+	require.NotNil(t, feed)
+	assert.Len(t, feed.Items, 2)
+
+	feed, err = fp.ParseJSONPages(server.URL+"/broken.json", nil)
+	httpErr, ok := err.(gofeed.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	require.NotNil(t, feed)
+	assert.Len(t, feed.Items, 1)
+
+	feed, err = fp.ParseJSONPages(server.URL+"/missing.json", nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, feed)
+}
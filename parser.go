@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/mmcdole/gofeed/atom"
 	"github.com/mmcdole/gofeed/json"
@@ -18,6 +19,10 @@ import (
 // out the Feed format
 var ErrFeedTypeNotDetected = errors.New("Failed to detect feed type")
 
+// ErrNotModified is returned when a conditional request reports
+// that the feed has not changed since it was last fetched
+var ErrNotModified = errors.New("Feed not modified")
+
 // HTTPError represents an HTTP error returned by a server.
 type HTTPError struct {
 	StatusCode int
@@ -92,37 +97,177 @@ func (f *Parser) ParseURL(feedURL string) (feed *Feed, err error) {
 // attempts to parse the response into the universal feed type.
 // Request could be canceled or timeout via given context
 func (f *Parser) ParseURLWithContext(feedURL string, ctx context.Context) (feed *Feed, err error) {
-	client := f.httpClient()
+	feed, _, err = f.fetchAndParse(feedURL, ctx, nil)
+	return feed, err
+}
 
-	req, err := http.NewRequest("GET", feedURL, nil)
+// ParseURLWithStore fetches the contents of a given url
+// with a conditional GET based on the state recorded in
+// store, and returns the parsed feed along with the items
+// that were not present on the previous fetch.
+// ErrNotModified is returned if the server reports that the
+// feed has not changed since the previous fetch.
+func (f *Parser) ParseURLWithStore(feedURL string, store Store) (feed *Feed, items []*Item, err error) {
+	return f.ParseURLWithStoreContext(feedURL, store, context.Background())
+}
+
+// ParseURLWithStoreContext is like ParseURLWithStore but
+// the request could be canceled or timeout via given context
+func (f *Parser) ParseURLWithStoreContext(feedURL string, store Store, ctx context.Context) (feed *Feed, items []*Item, err error) {
+	state, err := store.Get(feedURL)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if state == nil {
+		state = &FeedState{URL: feedURL}
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("User-Agent", f.UserAgent)
-	resp, err := client.Do(req)
 
+	headers := map[string]string{}
+	if state.ETag != "" {
+		headers["If-None-Match"] = state.ETag
+	}
+	if state.LastModified != "" {
+		headers["If-Modified-Since"] = state.LastModified
+	}
+
+	state.LastFetched = time.Now()
+	feed, resp, err := f.fetchAndParse(feedURL, ctx, headers)
 	if err != nil {
-		return nil, err
+		if resp != nil && resp.StatusCode == http.StatusNotModified {
+			err = ErrNotModified
+			state.ErrorCount = 0
+			state.LastError = ""
+		} else {
+			state.ErrorCount++
+			state.LastError = err.Error()
+		}
+		if perr := store.Put(state); perr != nil {
+			return nil, nil, perr
+		}
+		return nil, nil, err
 	}
 
-	if resp != nil {
-		defer func() {
-			ce := resp.Body.Close()
-			if ce != nil {
-				err = ce
+	// Match items by their FallbackIdentity instead of their
+	// Identity if the feed regenerated its GUIDs, as Diff does.
+	key := (*Item).Identity
+	guids := guidsByFallback(state.SeenItems, state.SeenFallbacks)
+	unstable := guidsChanged(guids, feed.Items)
+	if unstable {
+		key = (*Item).FallbackIdentity
+	}
+	seenItems, seenFallbacks := state.SeenItems, state.SeenFallbacks
+	seen := map[string]bool{}
+	for i, id := range seenItems {
+		if unstable {
+			id = ""
+			if i < len(seenFallbacks) {
+				id = seenFallbacks[i]
 			}
-		}()
+		}
+		seen[id] = true
+	}
+
+	// Record the items of the feed, followed by the items seen
+	// before which are no longer in it, so that an item which
+	// drops out of the feed is not new when it comes back.
+	state.SeenItems = []string{}
+	state.SeenFallbacks = []string{}
+	current := map[string]bool{}
+	for _, item := range feed.Items {
+		if item == nil {
+			continue
+		}
+		id := key(item)
+		if !seen[id] {
+			items = append(items, item)
+			seen[id] = true
+		}
+		current[id] = true
+		state.SeenItems = append(state.SeenItems, item.Identity())
+		state.SeenFallbacks = append(state.SeenFallbacks, item.FallbackIdentity())
+	}
+	for i, id := range seenItems {
+		if len(state.SeenItems) >= MaxSeenItems {
+			break
+		}
+		fallback := ""
+		if i < len(seenFallbacks) {
+			fallback = seenFallbacks[i]
+		}
+		k := id
+		if unstable {
+			k = fallback
+		}
+		if current[k] {
+			continue
+		}
+		current[k] = true
+		state.SeenItems = append(state.SeenItems, id)
+		state.SeenFallbacks = append(state.SeenFallbacks, fallback)
 	}
 
+	state.ETag = resp.Header.Get("ETag")
+	state.LastModified = resp.Header.Get("Last-Modified")
+	state.ErrorCount = 0
+	state.LastError = ""
+	if err := store.Put(state); err != nil {
+		return nil, nil, err
+	}
+
+	return feed, items, nil
+}
+
+func (f *Parser) fetchAndParse(feedURL string, ctx context.Context, headers map[string]string) (feed *Feed, resp *http.Response, err error) {
+	resp, err = f.fetchBody(feedURL, ctx, headers, func(body io.Reader) (err error) {
+		feed, err = f.Parse(body)
+		return err
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	return feed, resp, nil
+}
+
+// fetchBody fetches feedURL and passes the body of a successful
+// response to read.  A response with any other status is
+// returned along with an HTTPError.
+func (f *Parser) fetchBody(feedURL string, ctx context.Context, headers map[string]string, read func(body io.Reader) error) (resp *http.Response, err error) {
+	resp, err = f.fetch(feedURL, ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		ce := resp.Body.Close()
+		if ce != nil && err == nil {
+			err = ce
+		}
+	}()
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, HTTPError{
+		return resp, HTTPError{
 			StatusCode: resp.StatusCode,
 			Status:     resp.Status,
 		}
 	}
 
-	return f.Parse(resp.Body)
+	return resp, read(resp.Body)
+}
+
+func (f *Parser) fetch(feedURL string, ctx context.Context, headers map[string]string) (*http.Response, error) {
+	client := f.httpClient()
+
+	req, err := http.NewRequest("GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", f.UserAgent)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	return client.Do(req)
 }
 
 // ParseString parses a feed XML string and into the
@@ -0,0 +1,473 @@
+package gofeed
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mmcdole/gofeed/atom"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// ReverseTranslator converts the generic Feed struct into a
+// particular feed (atom.Feed or rss.Feed or json.Feed)
+type ReverseTranslator interface {
+	Translate(feed *Feed) (interface{}, error)
+}
+
+// DefaultRSSReverseTranslator converts the generic Feed
+// struct into an rss.Feed struct.
+//
+// This default implementation defines a set of
+// mapping rules between Feed -> rss.Feed
+// for each of the fields in rss.Feed.
+type DefaultRSSReverseTranslator struct{}
+
+// Translate converts the universal feed type into
+// an RSS 2.0 feed.
+func (t *DefaultRSSReverseTranslator) Translate(feed *Feed) (interface{}, error) {
+	if feed == nil {
+		return nil, fmt.Errorf("Feed must not be nil")
+	}
+
+	result := &rss.Feed{}
+	result.RootName = "rss"
+	result.Version = "2.0"
+	result.Title = feed.Title
+	result.Link = feed.Link
+	result.Description = feed.Description
+	result.Language = feed.Language
+	result.Copyright = feed.Copyright
+	result.ManagingEditor = t.translatePerson(t.firstPerson(feed.Author, feed.Authors))
+	result.PubDate = t.translateDate(feed.Published, feed.PublishedParsed)
+	result.PubDateParsed = feed.PublishedParsed
+	result.LastBuildDate = t.translateDate(feed.Updated, feed.UpdatedParsed)
+	result.LastBuildDateParsed = feed.UpdatedParsed
+	result.Generator = feed.Generator
+	result.Categories = t.translateCategories(feed.Categories)
+	result.Image = t.translateFeedImage(feed)
+	result.ITunesExt = feed.ITunesExt
+	result.PodcastExt = feed.PodcastExt
+	result.MediaExt = feed.MediaExt
+	result.SyndicationExt = feed.SyndicationExt
+	result.DublinCoreExt = feed.DublinCoreExt
+	result.DublinCoreTermsExt = feed.DublinCoreTermsExt
+	result.Extensions = t.translateFeedExtensions(feed)
+	result.Items = []*rss.Item{}
+	for _, item := range feed.Items {
+		if item == nil {
+			continue
+		}
+		result.Items = append(result.Items, t.translateItem(item))
+	}
+	return result, nil
+}
+
+func (t *DefaultRSSReverseTranslator) translateItem(item *Item) *rss.Item {
+	rssItem := &rss.Item{}
+	rssItem.Title = item.Title
+	rssItem.Link = item.Link
+	rssItem.Description = item.Description
+	rssItem.Content = item.Content
+	rssItem.Author = t.translatePerson(t.firstPerson(item.Author, item.Authors))
+	rssItem.Categories = t.translateCategories(item.Categories)
+	rssItem.Comments = item.CommentsLink
+	rssItem.PubDate = t.translateDate(item.Published, item.PublishedParsed)
+	rssItem.PubDateParsed = item.PublishedParsed
+	rssItem.ITunesExt = item.ITunesExt
+	rssItem.PodcastExt = item.PodcastExt
+	rssItem.MediaExt = item.MediaExt
+	rssItem.DublinCoreExt = item.DublinCoreExt
+	rssItem.DublinCoreTermsExt = item.DublinCoreTermsExt
+	rssItem.Extensions = item.Extensions
+	rssItem.Custom = item.Custom
+
+	if item.GUID != "" {
+		rssItem.GUID = &rss.GUID{Value: item.GUID}
+		if item.GUID != item.Link {
+			rssItem.GUID.IsPermalink = "false"
+		}
+	}
+
+	if len(item.Enclosures) > 0 && item.Enclosures[0] != nil {
+		e := item.Enclosures[0]
+		rssItem.Enclosure = &rss.Enclosure{URL: e.URL, Length: e.Length, Type: e.Type}
+	}
+
+	if item.Source != nil {
+		rssItem.Source = &rss.Source{Title: item.Source.Title, URL: item.Source.FeedLink}
+	}
+	return rssItem
+}
+
+func (t *DefaultRSSReverseTranslator) translateFeedImage(feed *Feed) (image *rss.Image) {
+	if feed.Image != nil && feed.Image.URL != "" {
+		image = &rss.Image{}
+		image.URL = feed.Image.URL
+		image.Title = feed.Image.Title
+		if image.Title == "" {
+			image.Title = feed.Title
+		}
+		image.Link = feed.Link
+	}
+	return
+}
+
+// translateFeedExtensions adds atom:link elements for the feed
+// link and the hubs of the feed which the extensions do not
+// already link to.
+func (t *DefaultRSSReverseTranslator) translateFeedExtensions(feed *Feed) ext.Extensions {
+	var links []ext.Extension
+	if feed.FeedLink != "" && !t.hasLink(feed.Extensions, "self", "") {
+		links = append(links, t.atomLink(map[string]string{"href": feed.FeedLink, "rel": "self", "type": "application/rss+xml"}))
+	}
+	for _, hub := range feed.Hubs {
+		if hub != "" && !t.hasLink(feed.Extensions, "hub", hub) {
+			links = append(links, t.atomLink(map[string]string{"href": hub, "rel": "hub"}))
+		}
+	}
+	if len(links) == 0 {
+		return feed.Extensions
+	}
+
+	extensions := ext.Extensions{}
+	for k, v := range feed.Extensions {
+		extensions[k] = v
+	}
+
+	atomExt := map[string][]ext.Extension{}
+	for k, v := range extensions["atom"] {
+		atomExt[k] = v
+	}
+	atomExt["link"] = append(links, atomExt["link"]...)
+	extensions["atom"] = atomExt
+	return extensions
+}
+
+func (t *DefaultRSSReverseTranslator) atomLink(attrs map[string]string) ext.Extension {
+	return ext.Extension{
+		Name:     "link",
+		Attrs:    attrs,
+		Children: map[string][]ext.Extension{},
+	}
+}
+
+// hasLink returns whether the extensions contain an atom:link
+// with the relation and, unless it is empty, the href.
+func (t *DefaultRSSReverseTranslator) hasLink(extensions ext.Extensions, rel, href string) bool {
+	for _, key := range []string{"atom", "atom10", "atom03"} {
+		for _, l := range extensions[key]["link"] {
+			if l.Attrs["rel"] == rel && (href == "" || l.Attrs["href"] == href) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *DefaultRSSReverseTranslator) translateCategories(categories []string) (cats []*rss.Category) {
+	for _, c := range categories {
+		cats = append(cats, &rss.Category{Value: c})
+	}
+	return
+}
+
+func (t *DefaultRSSReverseTranslator) translatePerson(person *Person) string {
+	if person == nil {
+		return ""
+	}
+	if person.Email != "" && person.Name != "" {
+		return fmt.Sprintf("%s (%s)", person.Email, person.Name)
+	}
+	if person.Email != "" {
+		return person.Email
+	}
+	return person.Name
+}
+
+func (t *DefaultRSSReverseTranslator) firstPerson(person *Person, persons []*Person) *Person {
+	if len(persons) > 0 {
+		return persons[0]
+	}
+	return person
+}
+
+func (t *DefaultRSSReverseTranslator) translateDate(date string, parsed *time.Time) string {
+	if parsed != nil {
+		return parsed.Format(time.RFC1123Z)
+	}
+	return date
+}
+
+// DefaultAtomReverseTranslator converts the generic Feed
+// struct into an atom.Feed struct.
+//
+// This default implementation defines a set of
+// mapping rules between Feed -> atom.Feed
+// for each of the fields in atom.Feed.
+type DefaultAtomReverseTranslator struct{}
+
+// Translate converts the universal feed type into
+// an Atom 1.0 feed.
+func (t *DefaultAtomReverseTranslator) Translate(feed *Feed) (interface{}, error) {
+	if feed == nil {
+		return nil, fmt.Errorf("Feed must not be nil")
+	}
+
+	result := &atom.Feed{}
+	result.Version = "1.0"
+	result.ID = t.translateFeedID(feed)
+	result.Title = feed.Title
+	result.Subtitle = feed.Description
+	result.Updated, result.UpdatedParsed = t.translateFeedUpdated(feed)
+	result.Links = t.translateLinks(feed.Link, feed.FeedLink)
+	for _, hub := range feed.Hubs {
+		result.Links = append(result.Links, &atom.Link{Href: hub, Rel: "hub"})
+	}
+	result.Language = feed.Language
+	result.Rights = feed.Copyright
+	result.Authors = t.translatePersons(feed.Author, feed.Authors)
+	result.Categories = t.translateCategories(feed.Categories)
+	result.Extensions = feed.Extensions
+
+	if feed.Generator != "" {
+		result.Generator = &atom.Generator{Value: feed.Generator}
+	}
+	if feed.Image != nil {
+		result.Logo = feed.Image.URL
+	}
+
+	result.Entries = []*atom.Entry{}
+	for _, item := range feed.Items {
+		if item == nil {
+			continue
+		}
+		result.Entries = append(result.Entries, t.translateItem(item))
+	}
+	return result, nil
+}
+
+func (t *DefaultAtomReverseTranslator) translateItem(item *Item) *atom.Entry {
+	entry := &atom.Entry{}
+	entry.ID = itemID(item)
+	entry.Title = item.Title
+	entry.Summary = item.Description
+	entry.Published = t.translateDate(item.Published, item.PublishedParsed)
+	entry.PublishedParsed = item.PublishedParsed
+	entry.Authors = t.translatePersons(item.Author, item.Authors)
+	entry.Categories = t.translateCategories(item.Categories)
+	entry.Links = t.translateLinks(item.Link, "")
+	entry.Extensions = item.Extensions
+
+	entry.Updated = t.translateDate(item.Updated, item.UpdatedParsed)
+	entry.UpdatedParsed = item.UpdatedParsed
+	if entry.Updated == "" {
+		entry.Updated = entry.Published
+		entry.UpdatedParsed = entry.PublishedParsed
+	}
+
+	if item.Content != "" {
+		entry.Content = &atom.Content{Type: "html", Value: item.Content}
+	}
+
+	for _, e := range item.Enclosures {
+		if e != nil {
+			entry.Links = append(entry.Links, &atom.Link{Href: e.URL, Rel: "enclosure", Type: e.Type, Length: e.Length})
+		}
+	}
+
+	if item.CommentsLink != "" {
+		entry.Links = append(entry.Links, &atom.Link{Href: item.CommentsLink, Rel: "replies", Type: "text/html"})
+	}
+
+	if item.License != nil {
+		entry.Rights = item.License.Text
+		if item.License.URL != "" {
+			entry.Links = append(entry.Links, &atom.Link{Href: item.License.URL, Rel: "license"})
+		}
+	}
+
+	if item.Source != nil {
+		entry.Source = &atom.Source{Title: item.Source.Title}
+		entry.Source.Links = t.translateLinks(item.Source.Link, item.Source.FeedLink)
+	}
+	return entry
+}
+
+// translateFeedID uses the feed or site link as the id, which
+// Atom requires.  Feeds without links get a urn:sha1 id derived
+// from their title and description.
+func (t *DefaultAtomReverseTranslator) translateFeedID(feed *Feed) string {
+	if feed.FeedLink != "" {
+		return feed.FeedLink
+	}
+	if feed.Link != "" {
+		return feed.Link
+	}
+	return "urn:sha1:" + hashFields(feed.Title, feed.Description)
+}
+
+func (t *DefaultAtomReverseTranslator) translateFeedUpdated(feed *Feed) (string, *time.Time) {
+	if feed.UpdatedParsed != nil || feed.Updated != "" {
+		return t.translateDate(feed.Updated, feed.UpdatedParsed), feed.UpdatedParsed
+	}
+	return t.translateDate(feed.Published, feed.PublishedParsed), feed.PublishedParsed
+}
+
+func (t *DefaultAtomReverseTranslator) translateLinks(link, feedLink string) (links []*atom.Link) {
+	if link != "" {
+		links = append(links, &atom.Link{Href: link, Rel: "alternate"})
+	}
+	if feedLink != "" {
+		links = append(links, &atom.Link{Href: feedLink, Rel: "self"})
+	}
+	return
+}
+
+func (t *DefaultAtomReverseTranslator) translatePersons(person *Person, persons []*Person) (authors []*atom.Person) {
+	if len(persons) == 0 && person != nil {
+		persons = []*Person{person}
+	}
+	for _, p := range persons {
+		if p != nil {
+			authors = append(authors, &atom.Person{Name: p.Name, Email: p.Email})
+		}
+	}
+	return
+}
+
+func (t *DefaultAtomReverseTranslator) translateCategories(categories []string) (cats []*atom.Category) {
+	for _, c := range categories {
+		cats = append(cats, &atom.Category{Term: c})
+	}
+	return
+}
+
+func (t *DefaultAtomReverseTranslator) translateDate(date string, parsed *time.Time) string {
+	if parsed != nil {
+		return parsed.Format(time.RFC3339)
+	}
+	return date
+}
+
+// DefaultJSONReverseTranslator converts the generic Feed
+// struct into a json.Feed struct.
+//
+// This default implementation defines a set of
+// mapping rules between Feed -> json.Feed
+// for each of the fields in json.Feed.
+type DefaultJSONReverseTranslator struct{}
+
+// Translate converts the universal feed type into
+// a JSON Feed 1.1 feed.
+func (t *DefaultJSONReverseTranslator) Translate(feed *Feed) (interface{}, error) {
+	if feed == nil {
+		return nil, fmt.Errorf("Feed must not be nil")
+	}
+
+	result := &json.Feed{}
+	result.Version = json.Version11
+	result.Title = feed.Title
+	result.HomePageURL = feed.Link
+	result.FeedURL = feed.FeedLink
+	result.Description = feed.Description
+	result.Language = feed.Language
+	result.Authors = t.translatePersons(feed.Author, feed.Authors)
+	result.Extensions = json.EncodeExtensions(feed.Extensions)
+	for _, hub := range feed.Hubs {
+		result.Hubs = append(result.Hubs, &json.Hub{Type: "WebSub", URL: hub})
+	}
+
+	if feed.Image != nil {
+		result.Icon = feed.Image.URL
+	}
+
+	result.Items = []*json.Item{}
+	for _, item := range feed.Items {
+		if item == nil {
+			continue
+		}
+		result.Items = append(result.Items, t.translateItem(item))
+	}
+	return result, nil
+}
+
+// translateItem maps an Item to a json.Item.  As JSON Feed
+// requires content, the description and then the title are
+// used as content when the item has none.
+func (t *DefaultJSONReverseTranslator) translateItem(item *Item) *json.Item {
+	jsonItem := &json.Item{}
+	jsonItem.ID = itemID(item)
+	jsonItem.URL = item.Link
+	jsonItem.Title = item.Title
+	jsonItem.Summary = item.Description
+	jsonItem.DatePublished = t.translateDate(item.Published, item.PublishedParsed)
+	jsonItem.DateModified = t.translateDate(item.Updated, item.UpdatedParsed)
+	jsonItem.Authors = t.translatePersons(item.Author, item.Authors)
+	jsonItem.Tags = item.Categories
+	jsonItem.Extensions = json.EncodeExtensions(item.Extensions)
+
+	if item.Content != "" {
+		jsonItem.ContentHTML = item.Content
+	} else if item.Description != "" {
+		jsonItem.ContentHTML = item.Description
+	} else {
+		jsonItem.ContentText = item.Title
+	}
+
+	if item.Image != nil {
+		jsonItem.Image = item.Image.URL
+	}
+
+	for _, e := range item.Enclosures {
+		if e == nil {
+			continue
+		}
+		attachment := json.Attachments{URL: e.URL, MimeType: e.Type}
+		if size, err := strconv.ParseInt(e.Length, 10, 64); err == nil {
+			attachment.SizeInBytes = size
+		}
+		jsonItem.Attachments = append(jsonItem.Attachments, attachment)
+	}
+	return jsonItem
+}
+
+func (t *DefaultJSONReverseTranslator) translatePersons(person *Person, persons []*Person) (authors []*json.Author) {
+	if len(persons) == 0 && person != nil {
+		persons = []*Person{person}
+	}
+	for _, p := range persons {
+		if p == nil || (p.Name == "" && p.Email == "") {
+			continue
+		}
+		author := &json.Author{Name: p.Name}
+		if p.Email != "" {
+			author.URL = "mailto:" + p.Email
+		}
+		authors = append(authors, author)
+	}
+	return
+}
+
+func (t *DefaultJSONReverseTranslator) translateDate(date string, parsed *time.Time) string {
+	if parsed != nil {
+		return parsed.Format(time.RFC3339)
+	}
+	return date
+}
+
+// itemID returns the GUID of the item, or else its
+// FallbackIdentity.  Hashes are written as urn:sha1 URNs so
+// that the id is an IRI, as Atom requires.
+func itemID(item *Item) string {
+	if item.GUID != "" {
+		return item.GUID
+	}
+	kind, id := item.fallback()
+	if kind == "hash" {
+		return "urn:sha1:" + id
+	}
+	return id
+}
@@ -0,0 +1,223 @@
+package gofeed_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reverseTranslatorFeed = `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+<title>Podcast</title>
+<link>http://example.com</link>
+<description>A podcast</description>
+<language>en</language>
+<pubDate>Mon, 06 Jan 2020 10:00:00 +0000</pubDate>
+<dc:publisher>Publisher</dc:publisher>
+<itunes:author>Host</itunes:author>
+<itunes:explicit>no</itunes:explicit>
+<itunes:category text="Technology"/>
+<item>
+<title>Episode 1</title>
+<link>http://example.com/1</link>
+<guid>urn:episode:1</guid>
+<description>First episode</description>
+<pubDate>Mon, 06 Jan 2020 10:00:00 +0000</pubDate>
+<enclosure url="http://example.com/1.mp3" length="1024" type="audio/mpeg"/>
+<dc:creator>Guest</dc:creator>
+<itunes:duration>10:00</itunes:duration>
+<itunes:episode>1</itunes:episode>
+</item>
+</channel>
+</rss>`
+
+func parseReverseTranslatorFeed(t *testing.T) *gofeed.Feed {
+	feed, err := gofeed.NewParser().ParseString(reverseTranslatorFeed)
+	require.Nil(t, err)
+	feed.FeedLink = "http://example.com/feed"
+	return feed
+}
+
+func TestFeed_WriteRSS(t *testing.T) {
+	expected := parseReverseTranslatorFeed(t)
+
+	var buf bytes.Buffer
+	require.Nil(t, expected.WriteRSS(&buf))
+
+	actual, err := gofeed.NewParser().Parse(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, "rss", actual.FeedType)
+	assert.Equal(t, expected.Title, actual.Title)
+	assert.Equal(t, expected.FeedLink, actual.FeedLink)
+	assert.Equal(t, expected.ITunesExt.Author, actual.ITunesExt.Author)
+	assert.Equal(t, expected.ITunesExt.Categories, actual.ITunesExt.Categories)
+	assert.Equal(t, expected.DublinCoreExt.Publisher, actual.DublinCoreExt.Publisher)
+	require.Len(t, actual.Items, 1)
+	assert.Equal(t, expected.Items[0].GUID, actual.Items[0].GUID)
+	assert.Equal(t, expected.Items[0].Enclosures, actual.Items[0].Enclosures)
+	assert.Equal(t, expected.Items[0].ITunesExt.Duration, actual.Items[0].ITunesExt.Duration)
+	assert.Equal(t, expected.Items[0].ITunesExt.Episode, actual.Items[0].ITunesExt.Episode)
+	assert.Equal(t, expected.Items[0].DublinCoreExt.Creator, actual.Items[0].DublinCoreExt.Creator)
+	assert.True(t, expected.Items[0].PublishedParsed.Equal(*actual.Items[0].PublishedParsed))
+}
+
+func TestFeed_WriteAtom(t *testing.T) {
+	expected := parseReverseTranslatorFeed(t)
+
+	var buf bytes.Buffer
+	require.Nil(t, expected.WriteAtom(&buf))
+
+	actual, err := gofeed.NewParser().Parse(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, "atom", actual.FeedType)
+	assert.Equal(t, expected.Title, actual.Title)
+	assert.Equal(t, expected.Link, actual.Link)
+	assert.Equal(t, expected.FeedLink, actual.FeedLink)
+	assert.Equal(t, expected.Extensions["itunes"], actual.Extensions["itunes"])
+	assert.Equal(t, expected.Extensions["dc"], actual.Extensions["dc"])
+	require.Len(t, actual.Items, 1)
+	assert.Equal(t, expected.Items[0].GUID, actual.Items[0].GUID)
+	assert.Equal(t, expected.Items[0].Enclosures, actual.Items[0].Enclosures)
+	assert.Equal(t, expected.Items[0].Extensions["itunes"], actual.Items[0].Extensions["itunes"])
+	assert.Equal(t, expected.Items[0].Extensions["dc"], actual.Items[0].Extensions["dc"])
+}
+
+func TestFeed_WriteJSON(t *testing.T) {
+	expected := parseReverseTranslatorFeed(t)
+
+	var buf bytes.Buffer
+	require.Nil(t, expected.WriteJSON(&buf))
+	assert.True(t, strings.Contains(buf.String(), `"version": "https://jsonfeed.org/version/1.1"`))
+	assert.True(t, strings.Contains(buf.String(), `"size_in_bytes": 1024`))
+
+	actual, err := gofeed.NewParser().Parse(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, "json", actual.FeedType)
+	assert.Equal(t, expected.Title, actual.Title)
+	assert.Equal(t, expected.Link, actual.Link)
+	assert.Equal(t, expected.FeedLink, actual.FeedLink)
+	require.Len(t, actual.Items, 1)
+	assert.Equal(t, expected.Items[0].GUID, actual.Items[0].GUID)
+	assert.Equal(t, expected.Items[0].Description, actual.Items[0].Content)
+	require.Len(t, actual.Items[0].Enclosures, 1)
+	assert.Equal(t, expected.Items[0].Enclosures[0].URL, actual.Items[0].Enclosures[0].URL)
+}
+
+func TestDefaultJSONReverseTranslator_Extensions(t *testing.T) {
+	data := `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Extensions",
+  "_microblog": {"about": "https://micro.blog/about/"},
+  "_version": 2,
+  "items": [
+    {
+      "id": "1",
+      "content_text": "content_text",
+      "_itunes": {"episode": 1, "explicit": false, "owner": {"name": "Owner"}, "categories": ["Technology", "News"]}
+    }
+  ]
+}`
+	expected, err := gofeed.NewParser().Parse(strings.NewReader(data))
+	require.Nil(t, err)
+
+	translator := &gofeed.DefaultJSONReverseTranslator{}
+	result, err := translator.Translate(expected)
+	require.Nil(t, err)
+	jsonFeed := result.(*json.Feed)
+	assert.JSONEq(t, `{"about": "https://micro.blog/about/"}`, string(jsonFeed.Extensions["_microblog"]))
+	assert.JSONEq(t, `2`, string(jsonFeed.Extensions["_version"]))
+	require.Len(t, jsonFeed.Items, 1)
+	assert.JSONEq(t, `{"episode": 1, "explicit": false, "owner": {"name": "Owner"}, "categories": ["Technology", "News"]}`, string(jsonFeed.Items[0].Extensions["_itunes"]))
+
+	var buf bytes.Buffer
+	require.Nil(t, expected.WriteJSON(&buf))
+	actual, err := gofeed.NewParser().Parse(&buf)
+	require.Nil(t, err)
+	assert.Equal(t, expected.Extensions, actual.Extensions)
+	require.Len(t, actual.Items, 1)
+	assert.Equal(t, expected.Items[0].Extensions, actual.Items[0].Extensions)
+}
+
+func TestDefaultReverseTranslators_Hubs(t *testing.T) {
+	feed := &gofeed.Feed{
+		Title:    "Title",
+		FeedLink: "http://example.com/feed",
+		Hubs:     []string{"http://hub.example.com/"},
+	}
+
+	for _, write := range []func(*gofeed.Feed, *bytes.Buffer) error{
+		func(f *gofeed.Feed, buf *bytes.Buffer) error { return f.WriteRSS(buf) },
+		func(f *gofeed.Feed, buf *bytes.Buffer) error { return f.WriteAtom(buf) },
+		func(f *gofeed.Feed, buf *bytes.Buffer) error { return f.WriteJSON(buf) },
+	} {
+		var buf bytes.Buffer
+		require.Nil(t, write(feed, &buf))
+		actual, err := gofeed.NewParser().Parse(&buf)
+		require.Nil(t, err)
+		assert.Equal(t, feed.Hubs, actual.Hubs, actual.FeedType)
+		assert.Equal(t, feed.FeedLink, actual.FeedLink, actual.FeedType)
+	}
+}
+
+func TestDefaultReverseTranslators_NilFeed(t *testing.T) {
+	translators := []gofeed.ReverseTranslator{
+		&gofeed.DefaultRSSReverseTranslator{},
+		&gofeed.DefaultAtomReverseTranslator{},
+		&gofeed.DefaultJSONReverseTranslator{},
+	}
+	for _, translator := range translators {
+		_, err := translator.Translate(nil)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestDefaultAtomReverseTranslator_IDs(t *testing.T) {
+	feed := &gofeed.Feed{
+		Title:   "No links",
+		Updated: "2020-01-06T10:00:00Z",
+		Author:  &gofeed.Person{Name: "Author"},
+		Items: []*gofeed.Item{
+			{Title: "Untitled", Updated: "2020-01-06T10:00:00Z"},
+		},
+	}
+
+	translated, err := (&gofeed.DefaultAtomReverseTranslator{}).Translate(feed)
+	require.Nil(t, err)
+	af := translated.(*atom.Feed)
+	assert.True(t, strings.HasPrefix(af.ID, "urn:sha1:"))
+	require.Len(t, af.Entries, 1)
+	assert.True(t, strings.HasPrefix(af.Entries[0].ID, "urn:sha1:"))
+
+	for _, finding := range validate.Atom(af) {
+		assert.False(t, strings.HasSuffix(finding.Path, "/id"), finding.String())
+	}
+}
+
+func TestDefaultReverseTranslators_NilItems(t *testing.T) {
+	feed := &gofeed.Feed{
+		Title: "Nil items",
+		Link:  "http://example.com",
+		Items: []*gofeed.Item{nil, {Title: "Item", Link: "http://example.com/1"}, nil},
+	}
+
+	var buf bytes.Buffer
+	for _, write := range []func(*bytes.Buffer) error{
+		func(w *bytes.Buffer) error { return feed.WriteRSS(w) },
+		func(w *bytes.Buffer) error { return feed.WriteAtom(w) },
+		func(w *bytes.Buffer) error { return feed.WriteJSON(w) },
+	} {
+		buf.Reset()
+		require.Nil(t, write(&buf))
+		actual, err := gofeed.NewParser().Parse(&buf)
+		require.Nil(t, err)
+		require.Len(t, actual.Items, 1, actual.FeedType)
+		assert.Equal(t, "Item", actual.Items[0].Title, actual.FeedType)
+	}
+}
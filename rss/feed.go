@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"html"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,37 +12,43 @@ import (
 	"github.com/mmcdole/gofeed/internal/shared"
 )
 
+const rss10NS = "http://purl.org/rss/1.0/"
+
 // Feed is an RSS Feed
 type Feed struct {
 	RootName  string     `json:"-" xml:"-"`
 	RootAttrs []xml.Attr `json:"-" xml:"-"`
 
-	Title               string                   `json:"title,omitempty"                xml:"title,omitempty"`
-	Link                string                   `json:"link,omitempty"                 xml:"link,omitempty"`
-	Description         string                   `json:"description,omitempty"          xml:"description,omitempty"`
-	Language            string                   `json:"language,omitempty"             xml:"language,omitempty"`
-	Copyright           string                   `json:"copyright,omitempty"            xml:"copyright,omitempty"`
-	ManagingEditor      string                   `json:"managingEditor,omitempty"       xml:"managingEditor,omitempty"`
-	WebMaster           string                   `json:"webMaster,omitempty"            xml:"webMaster,omitempty"`
-	PubDate             string                   `json:"pubDate,omitempty"              xml:"pubDate,omitempty"`
-	PubDateParsed       *time.Time               `json:"pubDateParsed,omitempty"`
-	LastBuildDate       string                   `json:"lastBuildDate,omitempty"        xml:"lastBuildDate,omitempty"`
-	LastBuildDateParsed *time.Time               `json:"lastBuildDateParsed,omitempty"`
-	Categories          []*Category              `json:"categories,omitempty"           xml:"categories,omitempty"`
-	Generator           string                   `json:"generator,omitempty"            xml:"generator,omitempty"`
-	Docs                string                   `json:"docs,omitempty"                 xml:"docs,omitempty"`
-	TTL                 string                   `json:"ttl,omitempty"                  xml:"ttl,omitempty"`
-	Image               *Image                   `json:"image,omitempty"                xml:"image,omitempty"`
-	Rating              string                   `json:"rating,omitempty"               xml:"rating,omitempty"`
-	SkipHours           []string                 `json:"skipHours,omitempty"            xml:"skipHours,omitempty"`
-	SkipDays            []string                 `json:"skipDays,omitempty"             xml:"skipDays,omitempty"`
-	Cloud               *Cloud                   `json:"cloud,omitempty"`
-	TextInput           *TextInput               `json:"textInput,omitempty"`
-	DublinCoreExt       *ext.DublinCoreExtension `json:"dcExt,omitempty"`
-	ITunesExt           *ext.ITunesFeedExtension `json:"itunesExt,omitempty"`
-	Extensions          ext.Extensions           `json:"extensions,omitempty"           xml:"-"`
-	Items               []*Item                  `json:"items"`
-	Version             string                   `json:"version"`
+	Title               string                        `json:"title,omitempty"                xml:"title,omitempty"`
+	Link                string                        `json:"link,omitempty"                 xml:"link,omitempty"`
+	Description         string                        `json:"description,omitempty"          xml:"description,omitempty"`
+	Language            string                        `json:"language,omitempty"             xml:"language,omitempty"`
+	Copyright           string                        `json:"copyright,omitempty"            xml:"copyright,omitempty"`
+	ManagingEditor      string                        `json:"managingEditor,omitempty"       xml:"managingEditor,omitempty"`
+	WebMaster           string                        `json:"webMaster,omitempty"            xml:"webMaster,omitempty"`
+	PubDate             string                        `json:"pubDate,omitempty"              xml:"pubDate,omitempty"`
+	PubDateParsed       *time.Time                    `json:"pubDateParsed,omitempty"`
+	LastBuildDate       string                        `json:"lastBuildDate,omitempty"        xml:"lastBuildDate,omitempty"`
+	LastBuildDateParsed *time.Time                    `json:"lastBuildDateParsed,omitempty"`
+	Categories          []*Category                   `json:"categories,omitempty"           xml:"categories,omitempty"`
+	Generator           string                        `json:"generator,omitempty"            xml:"generator,omitempty"`
+	Docs                string                        `json:"docs,omitempty"                 xml:"docs,omitempty"`
+	TTL                 string                        `json:"ttl,omitempty"                  xml:"ttl,omitempty"`
+	Image               *Image                        `json:"image,omitempty"                xml:"image,omitempty"`
+	Rating              string                        `json:"rating,omitempty"               xml:"rating,omitempty"`
+	SkipHours           []string                      `json:"skipHours,omitempty"            xml:"skipHours,omitempty"`
+	SkipDays            []string                      `json:"skipDays,omitempty"             xml:"skipDays,omitempty"`
+	Cloud               *Cloud                        `json:"cloud,omitempty"`
+	TextInput           *TextInput                    `json:"textInput,omitempty"`
+	DublinCoreExt       *ext.DublinCoreExtension      `json:"dcExt,omitempty"`
+	DublinCoreTermsExt  *ext.DublinCoreTermsExtension `json:"dctermsExt,omitempty"`
+	ITunesExt           *ext.ITunesFeedExtension      `json:"itunesExt,omitempty"`
+	PodcastExt          *ext.PodcastFeedExtension     `json:"podcastExt,omitempty"`
+	MediaExt            *ext.MediaExtension           `json:"mediaExt,omitempty"`
+	SyndicationExt      *ext.SyndicationExtension     `json:"syExt,omitempty"`
+	Extensions          ext.Extensions                `json:"extensions,omitempty"           xml:"-"`
+	Items               []*Item                       `json:"items"`
+	Version             string                        `json:"version"`
 }
 
 // Marshal the serialized xml for the parsed rss feed
@@ -65,16 +72,22 @@ func (f Feed) MarshalIndent(prefix, indent string) ([]byte, error) {
 }
 
 // MarshalXML is a custom xml marshaller function as the xml created from a feed has a unique
-// format we must capture
+// format we must capture. RSS 0.9 and 1.0 feeds are written back out with their RDF structure,
+// where the image, textinput and items are siblings of the channel.
 func (f Feed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	rdf := f.isRDF()
+
 	e.EncodeToken(xml.StartElement{
-		Name: xml.Name{Local: f.RootName},
-		Attr: f.RootAttrs,
-	})
-	e.EncodeToken(xml.StartElement{
-		Name: xml.Name{Local: "channel"},
+		Name: xml.Name{Local: f.rootName()},
+		Attr: f.rootAttrs(),
 	})
 
+	channel := xml.StartElement{Name: xml.Name{Local: "channel"}}
+	if rdf {
+		channel.Attr = rdfAttr("about", f.Link)
+	}
+	e.EncodeToken(channel)
+
 	encode(e, "title", f.Title)
 	encode(e, "link", f.Link)
 	encode(e, "description", f.Description)
@@ -88,28 +101,254 @@ func (f Feed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	encode(e, "generator", f.Generator)
 	encode(e, "docs", f.Docs)
 	encode(e, "ttl", f.TTL)
-	encode(e, "image", f.Image)
+	if rdf {
+		encodeRDFResources(e, f)
+	} else {
+		encode(e, "image", f.Image)
+	}
 	encode(e, "rating", f.Rating)
 	encodeStringArray(e, "skipHours", "hour", f.SkipHours)
 	encodeStringArray(e, "skipDays", "day", f.SkipDays)
-	encode(e, "textinput", f.TextInput)
+	if !rdf {
+		encode(e, "textinput", f.TextInput)
+	}
 	encode(e, "cloud", f.Cloud)
 
-	if f.ITunesExt != nil {
+	// The parser keeps every extension element in the Extensions map, so
+	// the typed extensions are only encoded when the map has no entries
+	// for them, as is the case for feeds that were built by hand.
+	if _, ok := f.Extensions["itunes"]; !ok && f.ITunesExt != nil {
 		f.ITunesExt.Encode(e)
-		encode(e, "itunes:title", f.Title)
 	}
 
-	if f.DublinCoreExt != nil {
+	if _, ok := f.Extensions["dc"]; !ok && f.DublinCoreExt != nil {
 		f.DublinCoreExt.Encode(e)
 	}
 
-	encode(e, "items", f.Items)
-	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "channel"}})
-	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: f.RootName}})
+	if _, ok := f.Extensions["dcterms"]; !ok && f.DublinCoreTermsExt != nil {
+		f.DublinCoreTermsExt.Encode(e)
+	}
+
+	if _, ok := f.Extensions["podcast"]; !ok && f.PodcastExt != nil {
+		f.PodcastExt.Encode(e)
+	}
+
+	if _, ok := f.Extensions["media"]; !ok && f.MediaExt != nil {
+		f.MediaExt.Encode(e)
+	}
+
+	if _, ok := f.Extensions["sy"]; !ok && f.SyndicationExt != nil {
+		f.SyndicationExt.Encode(e)
+	}
+
+	f.Extensions.Encode(e)
+
+	if !rdf {
+		for _, item := range f.Items {
+			encode(e, "item", item)
+		}
+	}
+	e.EncodeToken(xml.EndElement{Name: channel.Name})
+
+	if rdf {
+		if f.Image != nil {
+			encode(e, "image", f.Image, rdfAttr("about", f.Image.URL)...)
+		}
+		for _, item := range f.Items {
+			if item != nil {
+				encode(e, "item", item, rdfAttr("about", item.Link)...)
+			}
+		}
+		if f.TextInput != nil {
+			encode(e, "textinput", f.TextInput, rdfAttr("about", f.TextInput.Link)...)
+		}
+	}
+
+	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: f.rootName()}})
 	return nil
 }
 
+func (f Feed) isRDF() bool {
+	name := strings.ToLower(f.RootName)
+	return name == "rdf" || strings.HasSuffix(name, ":rdf")
+}
+
+func (f Feed) rootName() string {
+	if f.isRDF() {
+		return "rdf:RDF"
+	}
+	if f.RootName == "" {
+		return "rss"
+	}
+	return f.RootName
+}
+
+// rootAttrs returns the attributes of the root element, adding the version
+// and any namespace declarations that are needed by the feed but missing
+// from RootAttrs.
+func (f Feed) rootAttrs() []xml.Attr {
+	attrs := append([]xml.Attr{}, f.RootAttrs...)
+	declared := map[string]bool{}
+	for _, attr := range attrs {
+		if attr.Name.Space == "xmlns" {
+			declared[attr.Name.Local] = true
+		} else if strings.HasPrefix(attr.Name.Local, "xmlns:") {
+			declared[strings.TrimPrefix(attr.Name.Local, "xmlns:")] = true
+		} else if attr.Name.Space == "" {
+			declared[attr.Name.Local] = true
+		}
+	}
+
+	if f.isRDF() {
+		if !declared["xmlns"] {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: rss10NS})
+		}
+	} else if !declared["version"] {
+		version := f.Version
+		if version == "" && f.RootName == "" {
+			version = "2.0"
+		}
+		if version != "" {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "version"}, Value: version})
+		}
+	}
+
+	spaces := f.extensionNamespaces()
+	for _, prefix := range f.prefixes() {
+		if declared[prefix] {
+			continue
+		}
+		space := shared.NamespaceForPrefix(prefix)
+		if space == "" {
+			space = spaces[prefix]
+		}
+		if space != "" {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: space})
+		}
+	}
+	return attrs
+}
+
+// prefixes returns the sorted namespace prefixes of all the
+// elements that will be written for the feed.
+func (f Feed) prefixes() []string {
+	used := map[string]bool{}
+	if f.isRDF() {
+		used["rdf"] = true
+	}
+	if f.ITunesExt != nil {
+		used["itunes"] = true
+	}
+	if f.DublinCoreExt != nil {
+		used["dc"] = true
+	}
+	if f.DublinCoreTermsExt != nil {
+		used["dcterms"] = true
+	}
+	if f.PodcastExt != nil {
+		used["podcast"] = true
+	}
+	if f.MediaExt != nil {
+		used["media"] = true
+	}
+	if f.SyndicationExt != nil {
+		used["sy"] = true
+	}
+	for prefix := range f.Extensions {
+		used[prefix] = true
+	}
+	for _, item := range f.Items {
+		if item == nil {
+			continue
+		}
+		if item.Content != "" {
+			used["content"] = true
+		}
+		if item.ITunesExt != nil {
+			used["itunes"] = true
+		}
+		if item.DublinCoreExt != nil {
+			used["dc"] = true
+		}
+		if item.DublinCoreTermsExt != nil {
+			used["dcterms"] = true
+		}
+		if item.PodcastExt != nil {
+			used["podcast"] = true
+		}
+		if item.MediaExt != nil {
+			used["media"] = true
+		}
+		for prefix := range item.Extensions {
+			used[prefix] = true
+		}
+	}
+
+	for prefix := range f.extensionNamespaces() {
+		used[prefix] = true
+	}
+
+	prefixes := make([]string, 0, len(used))
+	for prefix := range used {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// extensionNamespaces returns the namespaces recorded on the
+// extension elements of the feed and its items, keyed by prefix,
+// so that prefixes which are not canonical can be declared.
+func (f Feed) extensionNamespaces() map[string]string {
+	spaces := f.Extensions.Namespaces()
+	for _, item := range f.Items {
+		if item == nil {
+			continue
+		}
+		for prefix, space := range item.Extensions.Namespaces() {
+			if _, ok := spaces[prefix]; !ok {
+				spaces[prefix] = space
+			}
+		}
+	}
+	return spaces
+}
+
+// encodeRDFResources writes the references from an RDF channel to
+// the image, items and textinput which follow it.
+func encodeRDFResources(e *xml.Encoder, f Feed) {
+	if f.Image != nil {
+		encodeEmpty(e, "image", rdfAttr("resource", f.Image.URL)...)
+	}
+
+	links := []string{}
+	for _, item := range f.Items {
+		if item != nil && item.Link != "" {
+			links = append(links, item.Link)
+		}
+	}
+	if len(links) > 0 {
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "items"}})
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "rdf:Seq"}})
+		for _, link := range links {
+			encodeEmpty(e, "rdf:li", rdfAttr("resource", link)...)
+		}
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "rdf:Seq"}})
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "items"}})
+	}
+
+	if f.TextInput != nil {
+		encodeEmpty(e, "textinput", rdfAttr("resource", f.TextInput.Link)...)
+	}
+}
+
+func rdfAttr(name, value string) []xml.Attr {
+	if value == "" {
+		return nil
+	}
+	return []xml.Attr{{Name: xml.Name{Local: "rdf:" + name}, Value: value}}
+}
+
 var applePodcastSpecialEncodings = map[string]string{
 	"©": "&#xA9;",
 	"℗": "&#x2117;",
@@ -121,6 +360,10 @@ var applePodcastSpecialEncodings = map[string]string{
 // This all stems from the issue that html escaping the escaped special characters makes the & become &amp;
 // which is encoding the special character twice
 func encodeCopyright(e *xml.Encoder, s string) {
+	if s == "" {
+		return
+	}
+
 	// If it is in a cdata tag don't mess with it and pass it on
 	if strings.Contains(s, shared.CDATA_START) && strings.Contains(s, shared.CDATA_END) {
 		encode(e, "copyright", s)
@@ -154,33 +397,36 @@ func (f Feed) String() string {
 type Item struct {
 	XMLName xml.Name `xml:"item"`
 
-	Title         string                   `json:"title,omitempty"          xml:"title,omitempty"`
-	Link          string                   `json:"link,omitempty"           xml:"link,omitempty"`
-	Description   string                   `json:"description,omitempty"    xml:"description,omitempty"`
-	Content       string                   `json:"content,omitempty"        xml:"content,omitempty"`
-	Author        string                   `json:"author,omitempty"         xml:"author,omitempty"`
-	Categories    []*Category              `json:"categories,omitempty"`
-	Comments      string                   `json:"comments,omitempty"       xml:"comments,omitempty"`
-	Enclosure     *Enclosure               `json:"enclosure,omitempty"`
-	GUID          *GUID                    `json:"guid,omitempty"`
-	PubDate       string                   `json:"pubDate,omitempty"        xml:"pubDate,omitempty"`
-	PubDateParsed *time.Time               `json:"pubDateParsed,omitempty"  xml:"-"`
-	Source        *Source                  `json:"source,omitempty"`
-	DublinCoreExt *ext.DublinCoreExtension `json:"dcExt,omitempty"`
-	ITunesExt     *ext.ITunesItemExtension `json:"itunesExt,omitempty"`
-	Extensions    ext.Extensions           `json:"extensions,omitempty"     xml:"-"`
-	Custom        map[string]string        `json:"custom,omitempty"`
+	Title              string                        `json:"title,omitempty"          xml:"title,omitempty"`
+	Link               string                        `json:"link,omitempty"           xml:"link,omitempty"`
+	Description        string                        `json:"description,omitempty"    xml:"description,omitempty"`
+	Content            string                        `json:"content,omitempty"        xml:"content,omitempty"`
+	Author             string                        `json:"author,omitempty"         xml:"author,omitempty"`
+	Categories         []*Category                   `json:"categories,omitempty"`
+	Comments           string                        `json:"comments,omitempty"       xml:"comments,omitempty"`
+	Enclosure          *Enclosure                    `json:"enclosure,omitempty"`
+	GUID               *GUID                         `json:"guid,omitempty"`
+	PubDate            string                        `json:"pubDate,omitempty"        xml:"pubDate,omitempty"`
+	PubDateParsed      *time.Time                    `json:"pubDateParsed,omitempty"  xml:"-"`
+	Source             *Source                       `json:"source,omitempty"`
+	DublinCoreExt      *ext.DublinCoreExtension      `json:"dcExt,omitempty"`
+	DublinCoreTermsExt *ext.DublinCoreTermsExtension `json:"dctermsExt,omitempty"`
+	ITunesExt          *ext.ITunesItemExtension      `json:"itunesExt,omitempty"`
+	PodcastExt         *ext.PodcastItemExtension     `json:"podcastExt,omitempty"`
+	MediaExt           *ext.MediaExtension           `json:"mediaExt,omitempty"`
+	Extensions         ext.Extensions                `json:"extensions,omitempty"     xml:"-"`
+	Custom             map[string]string             `json:"custom,omitempty"`
 }
 
 // MarshalXML is a custom xml marshaller for an item to allow for the itunes extension to
 // be flattened into the item serialization.
 func (i Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "item"}})
+	e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "item"}, Attr: start.Attr})
 
 	encode(e, "title", i.Title)
 	encode(e, "link", i.Link)
 	encode(e, "description", i.Description)
-	encode(e, "content", i.Content)
+	encode(e, "content:encoded", i.Content)
 	encode(e, "author", i.Author)
 	encode(e, "category", i.Categories)
 	encode(e, "comments", i.Comments)
@@ -188,19 +434,38 @@ func (i Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	encode(e, "guid", i.GUID)
 	encode(e, "pubDate", i.PubDate)
 	encode(e, "source", i.Source)
-	for k, v := range i.Custom {
-		encode(e, k, v)
+
+	custom := make([]string, 0, len(i.Custom))
+	for k := range i.Custom {
+		custom = append(custom, k)
+	}
+	sort.Strings(custom)
+	for _, k := range custom {
+		encode(e, k, i.Custom[k])
 	}
 
-	if i.ITunesExt != nil {
+	if _, ok := i.Extensions["itunes"]; !ok && i.ITunesExt != nil {
 		i.ITunesExt.Encode(e)
-		encode(e, "itunes:title", i.Title)
 	}
 
-	if i.DublinCoreExt != nil {
+	if _, ok := i.Extensions["dc"]; !ok && i.DublinCoreExt != nil {
 		i.DublinCoreExt.Encode(e)
 	}
 
+	if _, ok := i.Extensions["dcterms"]; !ok && i.DublinCoreTermsExt != nil {
+		i.DublinCoreTermsExt.Encode(e)
+	}
+
+	if _, ok := i.Extensions["podcast"]; !ok && i.PodcastExt != nil {
+		i.PodcastExt.Encode(e)
+	}
+
+	if _, ok := i.Extensions["media"]; !ok && i.MediaExt != nil {
+		i.MediaExt.Encode(e)
+	}
+
+	i.Extensions.Encode(e)
+
 	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "item"}})
 	return nil
 }
@@ -257,10 +522,10 @@ type Category struct {
 type TextInput struct {
 	XMLName xml.Name `xml:"textinput"`
 
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Link        string `json:"link,omitempty"`
+	Title       string `json:"title,omitempty"       xml:"title,omitempty"`
+	Description string `json:"description,omitempty" xml:"description,omitempty"`
+	Name        string `json:"name,omitempty"        xml:"name,omitempty"`
+	Link        string `json:"link,omitempty"        xml:"link,omitempty"`
 }
 
 // Cloud allows processes to register with a
@@ -301,3 +566,8 @@ func encodeStringArray(e *xml.Encoder, name, itemName string, val []string, attr
 	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
 	return nil
 }
+
+func encodeEmpty(e *xml.Encoder, name string, attrs ...xml.Attr) error {
+	e.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+	return e.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+}
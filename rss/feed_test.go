@@ -0,0 +1,112 @@
+package rss_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed_Marshal(t *testing.T) {
+	feedData := `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+<title>Title</title>
+<itunes:author>Author</itunes:author>
+<item>
+<title>Item 1</title>
+<content:encoded><![CDATA[<p>Content</p>]]></content:encoded>
+<media:group><media:content url="http://example.com/a.mp4" medium="video"/></media:group>
+</item>
+<item>
+<title>Item 2</title>
+</item>
+</channel>
+</rss>`
+
+	fp := &rss.Parser{}
+	feed, err := fp.Parse(strings.NewReader(feedData))
+	require.Nil(t, err)
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	xml := string(output)
+
+	assert.Equal(t, 2, strings.Count(xml, "<item>"))
+	assert.NotContains(t, xml, "<items>")
+	assert.NotContains(t, xml, "itunes:title")
+	assert.NotContains(t, xml, "itunes:image")
+	assert.Equal(t, 1, strings.Count(xml, "<itunes:author>"))
+	assert.Contains(t, xml, `<content:encoded>&lt;p&gt;Content&lt;/p&gt;</content:encoded>`)
+	assert.Contains(t, xml, `<media:group><media:content url="http://example.com/a.mp4" medium="video"></media:content></media:group>`)
+
+	fp = &rss.Parser{}
+	actual, err := fp.Parse(bytes.NewReader(output))
+	require.Nil(t, err)
+	assert.Equal(t, feed, actual)
+}
+
+func TestFeed_Marshal_RDF(t *testing.T) {
+	feedData := `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel rdf:about="http://example.com/">
+<title>Title</title>
+<link>http://example.com/</link>
+<items><rdf:Seq><rdf:li rdf:resource="http://example.com/1"/></rdf:Seq></items>
+</channel>
+<image rdf:about="http://example.com/logo.png">
+<title>Logo</title>
+<url>http://example.com/logo.png</url>
+</image>
+<item rdf:about="http://example.com/1">
+<title>Item</title>
+<link>http://example.com/1</link>
+<dc:date>2020-01-01T00:00:00Z</dc:date>
+</item>
+</rdf:RDF>`
+
+	fp := &rss.Parser{}
+	feed, err := fp.Parse(strings.NewReader(feedData))
+	require.Nil(t, err)
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	xml := string(output)
+
+	assert.Contains(t, xml, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">`)
+	assert.Contains(t, xml, `<channel rdf:about="http://example.com/">`)
+	assert.Contains(t, xml, `<items><rdf:Seq><rdf:li rdf:resource="http://example.com/1"></rdf:li></rdf:Seq></items></channel>`)
+	assert.Contains(t, xml, `<image rdf:about="http://example.com/logo.png">`)
+	assert.Contains(t, xml, `<item rdf:about="http://example.com/1">`)
+
+	fp = &rss.Parser{}
+	actual, err := fp.Parse(bytes.NewReader(output))
+	require.Nil(t, err)
+	assert.Equal(t, "1.0", actual.Version)
+	assert.Equal(t, feed, actual)
+}
+
+func TestFeed_Marshal_TypedExtensions(t *testing.T) {
+	feed := rss.Feed{
+		Title:     "Title",
+		ITunesExt: &ext.ITunesFeedExtension{Author: "Author"},
+		Items: []*rss.Item{
+			{Title: "Item", Content: "Content", DublinCoreExt: &ext.DublinCoreExtension{Creator: []string{"Creator"}}},
+		},
+	}
+
+	output, err := feed.Marshal()
+	require.Nil(t, err)
+	xml := string(output)
+
+	assert.Contains(t, xml, `<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">`)
+
+	fp := &rss.Parser{}
+	actual, err := fp.Parse(bytes.NewReader(output))
+	require.Nil(t, err)
+	assert.Equal(t, "Author", actual.ITunesExt.Author)
+	assert.Equal(t, "Content", actual.Items[0].Content)
+	assert.Equal(t, []string{"Creator"}, actual.Items[0].DublinCoreExt.Creator)
+}
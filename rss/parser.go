@@ -324,6 +324,22 @@ func (rp *Parser) parseChannel(p *xpp.XMLPullParser) (rss *Feed, err error) {
 		if dc, ok := rss.Extensions["dc"]; ok {
 			rss.DublinCoreExt = ext.NewDublinCoreExtension(dc)
 		}
+
+		if dcterms, ok := rss.Extensions["dcterms"]; ok {
+			rss.DublinCoreTermsExt = ext.NewDublinCoreTermsExtension(dcterms)
+		}
+
+		if podcast, ok := rss.Extensions["podcast"]; ok {
+			rss.PodcastExt = ext.NewPodcastFeedExtension(podcast)
+		}
+
+		if media, ok := rss.Extensions["media"]; ok {
+			rss.MediaExt = ext.NewMediaExtension(media)
+		}
+
+		if sy, ok := rss.Extensions["sy"]; ok {
+			rss.SyndicationExt = ext.NewSyndicationExtension(sy)
+		}
 	}
 
 	return rss, nil
@@ -460,6 +476,18 @@ func (rp *Parser) parseItem(p *xpp.XMLPullParser) (item *Item, err error) {
 		if dc, ok := item.Extensions["dc"]; ok {
 			item.DublinCoreExt = ext.NewDublinCoreExtension(dc)
 		}
+
+		if dcterms, ok := item.Extensions["dcterms"]; ok {
+			item.DublinCoreTermsExt = ext.NewDublinCoreTermsExtension(dcterms)
+		}
+
+		if podcast, ok := item.Extensions["podcast"]; ok {
+			item.PodcastExt = ext.NewPodcastItemExtension(podcast)
+		}
+
+		if media, ok := item.Extensions["media"]; ok {
+			item.MediaExt = ext.NewMediaExtension(media)
+		}
 	}
 
 	if err = p.Expect(xpp.EndTag, "item"); err != nil {
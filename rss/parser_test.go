@@ -14,7 +14,7 @@ import (
 )
 
 func TestParser_Parse(t *testing.T) {
-	files, _ := filepath.Glob("../testdata/parser/rss/rss_channel_copyright*.xml")
+	files, _ := filepath.Glob("../testdata/parser/rss/*.xml")
 	for _, f := range files {
 		testFile(t, f)
 	}
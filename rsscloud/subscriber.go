@@ -0,0 +1,557 @@
+// Package rsscloud implements an rssCloud subscriber which
+// registers with the cloud advertised by the cloud element of an
+// RSS feed and fetches the feed again when the cloud notifies it
+// of an update.
+// http://rsscloud.co/
+package rsscloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// Lease is how long a cloud keeps a registration.  Clouds drop
+// registrations which have not been renewed within 25 hours.
+const Lease = 25 * time.Hour
+
+// DefaultMaxContentLength is the size in bytes of the largest
+// notification or feed accepted when Subscriber does not set
+// MaxContentLength
+const DefaultMaxContentLength = 10 << 20
+
+// DefaultFetchTimeout is how long a fetch of a feed may take
+// when Subscriber does not set FetchTimeout
+const DefaultFetchTimeout = time.Minute
+
+// ErrUnsupportedProtocol is returned when the cloud uses a
+// protocol other than http-post and xml-rpc, such as soap
+var ErrUnsupportedProtocol = errors.New("Unsupported rssCloud protocol")
+
+// Subscription is a registration for notifications of updates
+// to a feed.  Expires is the time after which the cloud will
+// drop the registration unless Subscribe is called again.
+type Subscription struct {
+	FeedURL string
+	Cloud   rss.Cloud
+	Expires time.Time
+}
+
+// Subscriber registers with rssCloud servers for notifications
+// of updates to feeds.  It is an http.Handler which must be
+// reachable by the clouds at Callback, where it answers the
+// challenges of the clouds and receives their notifications.
+//
+// When a cloud notifies it of an update, the feed is fetched
+// with Client, parsed with Parser and passed to OnFeed.  Feeds
+// which cannot be fetched are passed to OnError instead.  A feed
+// is fetched by one request at a time, and the notifications
+// received while it is fetched lead to a single fetch once it
+// completes.  As a Parser is not safe for concurrent use, feeds
+// are parsed one at a time.  Notifications for subscriptions
+// which have expired are refused.  Notifications and feeds
+// larger than MaxContentLength are refused, and fetches which
+// take longer than FetchTimeout are canceled.
+type Subscriber struct {
+	Callback         string
+	NotifyProcedure  string
+	MaxContentLength int64
+	FetchTimeout     time.Duration
+	Parser           *gofeed.Parser
+	Client           *http.Client
+	OnFeed           func(sub Subscription, feed *gofeed.Feed)
+	OnError          func(sub Subscription, err error)
+
+	mu            sync.Mutex
+	parseMu       sync.Mutex
+	subscriptions map[string]*Subscription
+	fetching      map[string]bool
+}
+
+// NewSubscriber creates a Subscriber which is reachable by
+// clouds at the callback URL.  Notifications sent with xml-rpc
+// call the rssCloud.notify procedure.
+func NewSubscriber(callback string) *Subscriber {
+	return &Subscriber{
+		Callback:         callback,
+		NotifyProcedure:  "rssCloud.notify",
+		MaxContentLength: DefaultMaxContentLength,
+		FetchTimeout:     DefaultFetchTimeout,
+		Parser:           gofeed.NewParser(),
+		subscriptions:    map[string]*Subscription{},
+	}
+}
+
+// Subscribe registers with the cloud for notifications of
+// updates to the feed at feedURL
+func (s *Subscriber) Subscribe(feedURL string, cloud *rss.Cloud) (Subscription, error) {
+	return s.SubscribeWithContext(feedURL, cloud, context.Background())
+}
+
+// SubscribeWithContext registers with the cloud for notifications
+// of updates to the feed at feedURL with a custom context
+func (s *Subscriber) SubscribeWithContext(feedURL string, cloud *rss.Cloud, ctx context.Context) (Subscription, error) {
+	if cloud == nil || cloud.Domain == "" {
+		return Subscription{}, fmt.Errorf("Feed %s does not have a cloud", feedURL)
+	}
+
+	callback, err := url.Parse(s.Callback)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if callback.Hostname() == "" {
+		return Subscription{}, fmt.Errorf("Callback %q must be an absolute URL", s.Callback)
+	}
+
+	// Record the subscription before registering, as the
+	// cloud checks the callback with a challenge before
+	// it answers the request.
+	sub := &Subscription{FeedURL: feedURL, Cloud: *cloud, Expires: time.Now().Add(Lease)}
+	s.mu.Lock()
+	if s.subscriptions == nil {
+		s.subscriptions = map[string]*Subscription{}
+	}
+	previous, renewed := s.subscriptions[feedURL]
+	s.subscriptions[feedURL] = sub
+	s.mu.Unlock()
+
+	switch strings.ToLower(cloud.Protocol) {
+	case "http-post":
+		err = s.registerHTTPPost(ctx, feedURL, cloud, callback)
+	case "xml-rpc":
+		err = s.registerXMLRPC(ctx, feedURL, cloud, callback)
+	default:
+		err = ErrUnsupportedProtocol
+	}
+
+	if err != nil {
+		s.mu.Lock()
+		if renewed {
+			s.subscriptions[feedURL] = previous
+		} else {
+			delete(s.subscriptions, feedURL)
+		}
+		s.mu.Unlock()
+		return Subscription{}, err
+	}
+	return *sub, nil
+}
+
+// Subscriptions returns the subscriptions of the Subscriber
+func (s *Subscriber) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]Subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		result = append(result, *sub)
+	}
+	return result
+}
+
+// Restore adds subscriptions saved from Subscriptions, such as
+// after a restart, so that the notifications of the clouds are
+// received until the subscriptions expire.  The clouds are not
+// contacted.
+func (s *Subscriber) Restore(subs ...Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscriptions == nil {
+		s.subscriptions = map[string]*Subscription{}
+	}
+	for _, sub := range subs {
+		sub := sub
+		s.subscriptions[sub.FeedURL] = &sub
+	}
+}
+
+// ServeHTTP answers the challenges of clouds with GET and
+// receives their notifications with POST
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.challenge(w, r)
+	case http.MethodPost:
+		s.notify(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// challenge echoes the challenge of a cloud which checks that
+// the subscriber asked to be notified of updates to the feed
+func (s *Subscriber) challenge(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := s.subscription(query.Get("url")); !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, query.Get("challenge"))
+}
+
+// notify receives a notification sent as a form with http-post
+// or as a procedure call with xml-rpc, and fetches the feed
+func (s *Subscriber) notify(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, s.maxContentLength()))
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	xmlrpc := strings.Contains(r.Header.Get("Content-Type"), "xml")
+	var feedURL string
+	if xmlrpc {
+		feedURL, err = parseNotifyCall(body)
+		if err != nil {
+			writeXMLRPCFault(w, err.Error())
+			return
+		}
+	} else {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		feedURL = form.Get("url")
+	}
+
+	sub, ok := s.subscription(feedURL)
+	if !ok {
+		if xmlrpc {
+			writeXMLRPCFault(w, "Not subscribed to "+feedURL)
+		} else {
+			http.NotFound(w, r)
+		}
+		return
+	}
+
+	if xmlrpc {
+		writeXMLRPCResponse(w)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	// Clouds send notifications to many subscribers, so the
+	// feed is fetched after the notification is answered.
+	if s.startFetch(feedURL) {
+		go s.fetch(sub)
+	}
+}
+
+// startFetch reports whether a fetch of the feed must be
+// started.  If the feed is already being fetched, it is
+// fetched again once that fetch completes.
+func (s *Subscriber) startFetch(feedURL string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fetching == nil {
+		s.fetching = map[string]bool{}
+	}
+	if _, ok := s.fetching[feedURL]; ok {
+		s.fetching[feedURL] = true
+		return false
+	}
+	s.fetching[feedURL] = false
+	return true
+}
+
+// fetchAgain reports whether the feed was notified again while
+// it was being fetched, and marks the fetch done otherwise
+func (s *Subscriber) fetchAgain(feedURL string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fetching[feedURL] {
+		s.fetching[feedURL] = false
+		return true
+	}
+	delete(s.fetching, feedURL)
+	return false
+}
+
+func (s *Subscriber) fetch(sub Subscription) {
+	for {
+		feed, err := s.fetchFeed(sub.FeedURL)
+		if err != nil {
+			if s.OnError != nil {
+				s.OnError(sub, err)
+			}
+		} else if s.OnFeed != nil {
+			s.OnFeed(sub, feed)
+		}
+		if !s.fetchAgain(sub.FeedURL) {
+			return
+		}
+	}
+}
+
+func (s *Subscriber) fetchFeed(feedURL string) (*gofeed.Feed, error) {
+	timeout := s.FetchTimeout
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", s.parser().UserAgent)
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	limit := s.maxContentLength()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("Feed %s is larger than %d bytes", feedURL, limit)
+	}
+
+	s.parseMu.Lock()
+	defer s.parseMu.Unlock()
+	return s.parser().Parse(bytes.NewReader(body))
+}
+
+// subscription returns the subscription to the feed unless
+// there is none or it has expired
+func (s *Subscriber) subscription(feedURL string) (Subscription, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.subscriptions[feedURL]
+	if !ok || time.Now().After(sub.Expires) {
+		return Subscription{}, false
+	}
+	return *sub, true
+}
+
+// registerHTTPPost registers with a cloud which uses the
+// http-post protocol.  The cloud answers with a notifyResult
+// element.
+func (s *Subscriber) registerHTTPPost(ctx context.Context, feedURL string, cloud *rss.Cloud, callback *url.URL) error {
+	params := url.Values{}
+	params.Set("notifyProcedure", "")
+	params.Set("port", callbackPort(callback))
+	params.Set("path", callbackPath(callback))
+	params.Set("protocol", "http-post")
+	params.Set("domain", callback.Hostname())
+	params.Set("url1", feedURL)
+
+	body, err := s.post(ctx, cloudURL(cloud), "application/x-www-form-urlencoded", []byte(params.Encode()))
+	if err != nil {
+		return err
+	}
+
+	result := struct {
+		Success string `xml:"success,attr"`
+		Msg     string `xml:"msg,attr"`
+	}{}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("Invalid rssCloud response: %s", err)
+	}
+	if result.Success != "true" {
+		return fmt.Errorf("rssCloud registration failed: %s", result.Msg)
+	}
+	return nil
+}
+
+// registerXMLRPC registers with a cloud which uses the xml-rpc
+// protocol by calling its registerProcedure, which is usually
+// xmlStorageSystem.rssPleaseNotify.
+func (s *Subscriber) registerXMLRPC(ctx context.Context, feedURL string, cloud *rss.Cloud, callback *url.URL) error {
+	procedure := cloud.RegisterProcedure
+	if procedure == "" {
+		procedure = "xmlStorageSystem.rssPleaseNotify"
+	}
+	port, _ := strconv.Atoi(callbackPort(callback))
+
+	var call bytes.Buffer
+	call.WriteString(`<?xml version="1.0"?><methodCall><methodName>`)
+	xml.EscapeText(&call, []byte(procedure))
+	call.WriteString(`</methodName><params>`)
+	writeXMLRPCParam(&call, "string", s.NotifyProcedure)
+	writeXMLRPCParam(&call, "i4", strconv.Itoa(port))
+	writeXMLRPCParam(&call, "string", callbackPath(callback))
+	writeXMLRPCParam(&call, "string", "xml-rpc")
+	call.WriteString(`<param><value><array><data><value><string>`)
+	xml.EscapeText(&call, []byte(feedURL))
+	call.WriteString(`</string></value></data></array></value></param>`)
+	writeXMLRPCParam(&call, "string", callback.Hostname())
+	call.WriteString(`</params></methodCall>`)
+
+	body, err := s.post(ctx, cloudURL(cloud), "text/xml", call.Bytes())
+	if err != nil {
+		return err
+	}
+
+	response := struct {
+		Value struct {
+			Boolean string `xml:"boolean"`
+		} `xml:"params>param>value"`
+		Fault []struct {
+			Name   string `xml:"name"`
+			String string `xml:"value>string"`
+		} `xml:"fault>value>struct>member"`
+	}{}
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("Invalid rssCloud response: %s", err)
+	}
+	for _, member := range response.Fault {
+		if member.Name == "faultString" {
+			return fmt.Errorf("rssCloud registration failed: %s", member.String)
+		}
+	}
+	if b := strings.TrimSpace(response.Value.Boolean); b != "1" && b != "true" {
+		return fmt.Errorf("rssCloud registration failed")
+	}
+	return nil
+}
+
+func (s *Subscriber) post(ctx context.Context, endpoint, contentType string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", s.parser().UserAgent)
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (s *Subscriber) parser() *gofeed.Parser {
+	if s.Parser != nil {
+		return s.Parser
+	}
+	return gofeed.NewParser()
+}
+
+func (s *Subscriber) maxContentLength() int64 {
+	if s.MaxContentLength > 0 {
+		return s.MaxContentLength
+	}
+	return DefaultMaxContentLength
+}
+
+func (s *Subscriber) client() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+// cloudURL returns the URL of the cloud described by the
+// cloud element, which defaults to port 80
+func cloudURL(cloud *rss.Cloud) string {
+	port := strings.TrimSpace(cloud.Port)
+	if port == "" {
+		port = "80"
+	}
+	path := cloud.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "http://" + net.JoinHostPort(strings.TrimSpace(cloud.Domain), port) + path
+}
+
+func callbackPort(callback *url.URL) string {
+	if port := callback.Port(); port != "" {
+		return port
+	}
+	if callback.Scheme == "https" {
+		return "443"
+	}
+	return "80"
+}
+
+func callbackPath(callback *url.URL) string {
+	path := callback.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if callback.RawQuery != "" {
+		path += "?" + callback.RawQuery
+	}
+	return path
+}
+
+func writeXMLRPCParam(w *bytes.Buffer, kind, value string) {
+	w.WriteString("<param><value><" + kind + ">")
+	xml.EscapeText(w, []byte(value))
+	w.WriteString("</" + kind + "></value></param>")
+}
+
+// parseNotifyCall returns the feed URL which is the only
+// parameter of an xml-rpc notification
+func parseNotifyCall(body []byte) (string, error) {
+	call := struct {
+		MethodName string `xml:"methodName"`
+		Params     []struct {
+			String string `xml:"string"`
+			Value  string `xml:",chardata"`
+		} `xml:"params>param>value"`
+	}{}
+	if err := xml.Unmarshal(body, &call); err != nil {
+		return "", fmt.Errorf("Invalid xml-rpc call: %s", err)
+	}
+	if len(call.Params) == 0 {
+		return "", fmt.Errorf("Notification %s has no url", call.MethodName)
+	}
+
+	// Values without a type are strings in xml-rpc
+	feedURL := call.Params[0].String
+	if feedURL == "" {
+		feedURL = call.Params[0].Value
+	}
+	return strings.TrimSpace(feedURL), nil
+}
+
+func writeXMLRPCResponse(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/xml")
+	io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+}
+
+func writeXMLRPCFault(w http.ResponseWriter, msg string) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0"?><methodResponse><fault><value><struct>`)
+	buf.WriteString(`<member><name>faultCode</name><value><int>1</int></value></member>`)
+	buf.WriteString(`<member><name>faultString</name><value><string>`)
+	xml.EscapeText(&buf, []byte(msg))
+	buf.WriteString(`</string></value></member></struct></value></fault></methodResponse>`)
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,298 @@
+package rsscloud_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/mmcdole/gofeed/rsscloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cloudFeed = `<rss version="2.0">
+<channel>
+<title>Cloud</title>
+<cloud domain="%s" port="%s" path="/RPC2" registerProcedure="xmlStorageSystem.rssPleaseNotify" protocol="%s"/>
+<item><title>Updated</title></item>
+</channel>
+</rss>`
+
+// stubCloud is an rssCloud server which challenges the
+// subscribers that register with http-post, and can notify
+// the subscribers that registered.
+type stubCloud struct {
+	t        *testing.T
+	refuse   bool
+	notify   string
+	protocol string
+}
+
+func (c *stubCloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	if c.protocol == "xml-rpc" {
+		call := struct {
+			MethodName string   `xml:"methodName"`
+			Params     []string `xml:"params>param>value>string"`
+			Port       string   `xml:"params>param>value>i4"`
+			URLs       []string `xml:"params>param>value>array>data>value>string"`
+		}{}
+		require.Nil(c.t, xml.Unmarshal(body, &call))
+		assert.Equal(c.t, "xmlStorageSystem.rssPleaseNotify", call.MethodName)
+		require.Len(c.t, call.Params, 4)
+		assert.Equal(c.t, "rssCloud.notify", call.Params[0])
+		assert.Equal(c.t, "xml-rpc", call.Params[2])
+		require.Len(c.t, call.URLs, 1)
+		c.notify = fmt.Sprintf("http://%s%s", net.JoinHostPort(call.Params[3], call.Port), call.Params[1])
+
+		w.Header().Set("Content-Type", "text/xml")
+		if c.refuse {
+			io.WriteString(w, `<?xml version="1.0"?><methodResponse><fault><value><struct><member><name>faultCode</name><value><int>4</int></value></member><member><name>faultString</name><value><string>Refused</string></value></member></struct></value></fault></methodResponse>`)
+			return
+		}
+		io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	require.Nil(c.t, err)
+	assert.Equal(c.t, "http-post", form.Get("protocol"))
+	c.notify = fmt.Sprintf("http://%s%s", net.JoinHostPort(form.Get("domain"), form.Get("port")), form.Get("path"))
+
+	resp, err := http.Get(c.notify + "?url=" + url.QueryEscape(form.Get("url1")) + "&challenge=abc")
+	require.Nil(c.t, err)
+	challenge, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if c.refuse || string(challenge) != "abc" {
+		io.WriteString(w, `<?xml version="1.0"?><notifyResult success="false" msg="The challenge failed."/>`)
+		return
+	}
+	io.WriteString(w, `<?xml version="1.0"?><notifyResult success="true" msg="Thanks for the registration."/>`)
+}
+
+// ping notifies the subscriber that the feed was updated
+func (c *stubCloud) ping(feedURL string) int {
+	var resp *http.Response
+	var err error
+	if c.protocol == "xml-rpc" {
+		call := `<?xml version="1.0"?><methodCall><methodName>rssCloud.notify</methodName><params><param><value>` + feedURL + `</value></param></params></methodCall>`
+		resp, err = http.Post(c.notify, "text/xml", strings.NewReader(call))
+	} else {
+		resp, err = http.PostForm(c.notify, url.Values{"url": {feedURL}})
+	}
+	require.Nil(c.t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func testSubscriber(t *testing.T, protocol string) {
+	cloud := &stubCloud{t: t, protocol: protocol}
+	cloudServer := httptest.NewServer(cloud)
+	defer cloudServer.Close()
+	cloudURL, _ := url.Parse(cloudServer.URL)
+
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, cloudFeed, cloudURL.Hostname(), cloudURL.Port(), protocol)
+	}))
+	defer feedServer.Close()
+
+	subscriber := rsscloud.NewSubscriber("")
+	subscriberServer := httptest.NewServer(subscriber)
+	defer subscriberServer.Close()
+	subscriber.Callback = subscriberServer.URL + "/notify"
+
+	feeds := make(chan *gofeed.Feed, 1)
+	subscriber.OnFeed = func(sub rsscloud.Subscription, feed *gofeed.Feed) { feeds <- feed }
+
+	rssFeed, err := (&rss.Parser{}).Parse(strings.NewReader(fmt.Sprintf(cloudFeed, cloudURL.Hostname(), cloudURL.Port(), protocol)))
+	require.Nil(t, err)
+
+	sub, err := subscriber.Subscribe(feedServer.URL, rssFeed.Cloud)
+	require.Nil(t, err)
+	assert.Equal(t, feedServer.URL, sub.FeedURL)
+	assert.WithinDuration(t, time.Now().Add(rsscloud.Lease), sub.Expires, time.Minute)
+	assert.Len(t, subscriber.Subscriptions(), 1)
+
+	assert.Equal(t, http.StatusOK, cloud.ping(feedServer.URL))
+	select {
+	case feed := <-feeds:
+		assert.Equal(t, "Cloud", feed.Title)
+		require.Len(t, feed.Items, 1)
+		assert.Equal(t, "Updated", feed.Items[0].Title)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Feed was not fetched after the notification")
+	}
+
+	if protocol == "http-post" {
+		assert.Equal(t, http.StatusNotFound, cloud.ping("http://example.com/other"))
+	}
+
+	cloud.refuse = true
+	_, err = subscriber.Subscribe("http://example.com/refused", rssFeed.Cloud)
+	assert.NotNil(t, err)
+	assert.Len(t, subscriber.Subscriptions(), 1)
+}
+
+func TestSubscriber_HTTPPost(t *testing.T) {
+	testSubscriber(t, "http-post")
+}
+
+func TestSubscriber_XMLRPC(t *testing.T) {
+	testSubscriber(t, "xml-rpc")
+}
+
+func TestSubscriber_ConcurrentNotifications(t *testing.T) {
+	cloud := &stubCloud{t: t, protocol: "http-post"}
+	cloudServer := httptest.NewServer(cloud)
+	defer cloudServer.Close()
+	cloudURL, _ := url.Parse(cloudServer.URL)
+
+	var fetches int32
+	release := make(chan struct{})
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			<-release
+		}
+		fmt.Fprintf(w, cloudFeed, cloudURL.Hostname(), cloudURL.Port(), "http-post")
+	}))
+	defer feedServer.Close()
+
+	subscriber := rsscloud.NewSubscriber("")
+	subscriberServer := httptest.NewServer(subscriber)
+	defer subscriberServer.Close()
+	subscriber.Callback = subscriberServer.URL + "/notify"
+
+	feeds := make(chan *gofeed.Feed, 50)
+	subscriber.OnFeed = func(sub rsscloud.Subscription, feed *gofeed.Feed) { feeds <- feed }
+	subscriber.OnError = func(sub rsscloud.Subscription, err error) { t.Error(err) }
+
+	_, err := subscriber.Subscribe(feedServer.URL, &rss.Cloud{Domain: cloudURL.Hostname(), Port: cloudURL.Port(), Path: "/RPC2", Protocol: "http-post"})
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, http.StatusOK, cloud.ping(feedServer.URL))
+		}()
+	}
+	wg.Wait()
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case feed := <-feeds:
+			assert.Equal(t, "Cloud", feed.Title)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Feed was not fetched after the notifications")
+		}
+	}
+	select {
+	case <-feeds:
+		t.Fatal("Notifications received during a fetch were not collapsed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func TestSubscriber_Subscribe_Errors(t *testing.T) {
+	subscriber := rsscloud.NewSubscriber("http://example.com/notify")
+
+	_, err := subscriber.Subscribe("http://example.com/feed", nil)
+	assert.NotNil(t, err)
+
+	_, err = subscriber.Subscribe("http://example.com/feed", &rss.Cloud{Domain: "example.com", Protocol: "soap"})
+	assert.Equal(t, rsscloud.ErrUnsupportedProtocol, err)
+	assert.Empty(t, subscriber.Subscriptions())
+
+	subscriber.Callback = "/notify"
+	_, err = subscriber.Subscribe("http://example.com/feed", &rss.Cloud{Domain: "example.com", Protocol: "http-post"})
+	assert.NotNil(t, err)
+}
+
+func TestSubscriber_Challenge(t *testing.T) {
+	subscriber := rsscloud.NewSubscriber("http://example.com/notify")
+
+	w := httptest.NewRecorder()
+	subscriber.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/notify?url=http://example.com/feed&challenge=abc", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	subscriber.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/notify", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+// notifyForm sends an http-post notification for feedURL
+func notifyForm(subscriber *rsscloud.Subscriber, feedURL string) int {
+	form := url.Values{"url": {feedURL}}
+	r := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	subscriber.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestSubscriber_Expired(t *testing.T) {
+	subscriber := rsscloud.NewSubscriber("http://example.com/notify")
+	subscriber.Restore(rsscloud.Subscription{
+		FeedURL: "http://example.com/feed",
+		Expires: time.Now().Add(-time.Minute),
+	})
+	assert.Len(t, subscriber.Subscriptions(), 1)
+
+	w := httptest.NewRecorder()
+	subscriber.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/notify?url=http://example.com/feed&challenge=abc", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, http.StatusNotFound, notifyForm(subscriber, "http://example.com/feed"))
+}
+
+func TestSubscriber_Limits(t *testing.T) {
+	release := make(chan struct{})
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stalled" {
+			<-release
+			return
+		}
+		fmt.Fprintf(w, cloudFeed, "example.com", "80", "http-post")
+	}))
+	defer feedServer.Close()
+	defer close(release)
+
+	subscriber := rsscloud.NewSubscriber("http://example.com/notify")
+	subscriber.MaxContentLength = 64
+	subscriber.FetchTimeout = 100 * time.Millisecond
+	errs := make(chan error, 2)
+	subscriber.OnError = func(sub rsscloud.Subscription, err error) { errs <- err }
+	subscriber.OnFeed = func(sub rsscloud.Subscription, feed *gofeed.Feed) { t.Error("Feed was not refused") }
+	subscriber.Restore(
+		rsscloud.Subscription{FeedURL: feedServer.URL + "/large", Expires: time.Now().Add(time.Hour)},
+		rsscloud.Subscription{FeedURL: feedServer.URL + "/stalled", Expires: time.Now().Add(time.Hour)},
+	)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, notifyForm(subscriber, feedServer.URL+"/"+strings.Repeat("x", 64)))
+
+	for _, path := range []string{"/large", "/stalled"} {
+		assert.Equal(t, http.StatusOK, notifyForm(subscriber, feedServer.URL+path))
+		select {
+		case err := <-errs:
+			assert.NotNil(t, err, path)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Fetch of " + path + " was not refused")
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package gofeed
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// MaxSeenItems is the number of items a FeedState remembers.
+// Items which dropped out of the feed are forgotten first.
+const MaxSeenItems = 1000
+
+// FeedState is the state a poller needs to remember
+// between fetches of a feed.
+type FeedState struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	LastFetched  time.Time `json:"lastFetched,omitempty"`
+	ErrorCount   int       `json:"errorCount,omitempty"`
+	LastError    string    `json:"lastError,omitempty"`
+	SeenItems    []string  `json:"seenItems,omitempty"`
+
+	// SeenFallbacks holds the FallbackIdentity of each item in
+	// SeenItems, so that feeds which regenerate their GUIDs on
+	// every fetch can be detected (see UnstableGUIDs).
+	SeenFallbacks []string `json:"seenFallbacks,omitempty"`
+}
+
+// Store persists FeedState values keyed by feed URL.
+// Get returns a nil state and a nil error if no state
+// has been recorded for the given URL.
+type Store interface {
+	Get(feedURL string) (*FeedState, error)
+	Put(state *FeedState) error
+	Delete(feedURL string) error
+}
+
+// MemoryStore is a Store which keeps all state in memory.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	states map[string]*FeedState
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: map[string]*FeedState{}}
+}
+
+// Get returns a copy of the state recorded for feedURL.
+func (s *MemoryStore) Get(feedURL string) (*FeedState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyState(s.states[feedURL]), nil
+}
+
+// Put records a copy of state under state.URL.
+func (s *MemoryStore) Put(state *FeedState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.URL] = copyState(state)
+	return nil
+}
+
+// Delete removes the state recorded for feedURL.
+func (s *MemoryStore) Delete(feedURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, feedURL)
+	return nil
+}
+
+// FileStore is a Store which keeps all state in a single
+// JSON file on disk.  The file is rewritten atomically on
+// every change.  It is safe for concurrent use within a
+// single process.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+	mem  *MemoryStore
+}
+
+// NewFileStore opens the FileStore at path, loading any
+// previously saved state.  The file is created on the
+// first write if it does not exist.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, mem: NewMemoryStore()}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.mem.states); err != nil {
+			return nil, err
+		}
+	}
+	if s.mem.states == nil {
+		s.mem.states = map[string]*FeedState{}
+	}
+	return s, nil
+}
+
+// Get returns a copy of the state recorded for feedURL.
+func (s *FileStore) Get(feedURL string) (*FeedState, error) {
+	return s.mem.Get(feedURL)
+}
+
+// Put records state under state.URL and saves the file.
+func (s *FileStore) Put(state *FeedState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mem.Put(state)
+	return s.save()
+}
+
+// Delete removes the state recorded for feedURL and
+// saves the file.
+func (s *FileStore) Delete(feedURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mem.Delete(feedURL)
+	return s.save()
+}
+
+func (s *FileStore) save() error {
+	s.mem.mu.RLock()
+	data, err := json.MarshalIndent(s.mem.states, "", "    ")
+	s.mem.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func copyState(state *FeedState) *FeedState {
+	if state == nil {
+		return nil
+	}
+	c := *state
+	if state.SeenItems != nil {
+		c.SeenItems = append([]string{}, state.SeenItems...)
+	}
+	if state.SeenFallbacks != nil {
+		c.SeenFallbacks = append([]string{}, state.SeenFallbacks...)
+	}
+	return &c
+}
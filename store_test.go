@@ -0,0 +1,216 @@
+package gofeed_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, gofeed.NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gofeed")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state.json")
+	store, err := gofeed.NewFileStore(path)
+	require.Nil(t, err)
+	testStore(t, store)
+
+	err = store.Put(&gofeed.FeedState{URL: "http://example.com/feed", ETag: "abc"})
+	require.Nil(t, err)
+
+	reopened, err := gofeed.NewFileStore(path)
+	require.Nil(t, err)
+	state, err := reopened.Get("http://example.com/feed")
+	require.Nil(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, "abc", state.ETag)
+
+	require.Nil(t, ioutil.WriteFile(path, []byte("null"), 0644))
+	reopened, err = gofeed.NewFileStore(path)
+	require.Nil(t, err)
+	assert.Nil(t, reopened.Put(&gofeed.FeedState{URL: "http://example.com/feed"}))
+}
+
+func testStore(t *testing.T, store gofeed.Store) {
+	state, err := store.Get("http://example.com/feed")
+	assert.Nil(t, err)
+	assert.Nil(t, state)
+
+	err = store.Put(&gofeed.FeedState{
+		URL:       "http://example.com/feed",
+		ETag:      "etag",
+		SeenItems: []string{"1", "2"},
+	})
+	assert.Nil(t, err)
+
+	state, err = store.Get("http://example.com/feed")
+	assert.Nil(t, err)
+	assert.Equal(t, "etag", state.ETag)
+	assert.Equal(t, []string{"1", "2"}, state.SeenItems)
+
+	err = store.Delete("http://example.com/feed")
+	assert.Nil(t, err)
+	state, err = store.Get("http://example.com/feed")
+	assert.Nil(t, err)
+	assert.Nil(t, state)
+}
+
+func TestParser_ParseURLWithStore(t *testing.T) {
+	body := `<rss version="2.0"><channel><title>Feed</title>
+<item><guid>1</guid></item>
+<item><guid>2</guid></item>
+</channel></rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, body)
+	}))
+	defer server.Close()
+
+	store := gofeed.NewMemoryStore()
+	store.Put(&gofeed.FeedState{URL: server.URL, SeenItems: []string{"guid:1"}})
+
+	fp := gofeed.NewParser()
+	feed, items, err := fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	assert.Equal(t, "Feed", feed.Title)
+	require.Len(t, items, 1)
+	assert.Equal(t, "2", items[0].GUID)
+
+	state, _ := store.Get(server.URL)
+	assert.Equal(t, `"v1"`, state.ETag)
+	assert.Equal(t, []string{"guid:1", "guid:2"}, state.SeenItems)
+	assert.False(t, state.LastFetched.IsZero())
+
+	feed, items, err = fp.ParseURLWithStore(server.URL, store)
+	assert.Equal(t, gofeed.ErrNotModified, err)
+	assert.Nil(t, feed)
+	assert.Nil(t, items)
+
+	state, _ = store.Get(server.URL)
+	assert.Equal(t, 0, state.ErrorCount)
+}
+
+func TestParser_ParseURLWithStore_Failure(t *testing.T) {
+	server, client := mockServerResponse(500, "", 0)
+	store := gofeed.NewMemoryStore()
+
+	fp := gofeed.NewParser()
+	fp.Client = client
+	_, _, err := fp.ParseURLWithStore(server.URL, store)
+	assert.IsType(t, gofeed.HTTPError{}, err)
+
+	state, _ := store.Get(server.URL)
+	require.NotNil(t, state)
+	assert.Equal(t, 1, state.ErrorCount)
+	assert.NotEmpty(t, state.LastError)
+}
+
+func TestParser_ParseURLWithStore_UnstableGUIDs(t *testing.T) {
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		fmt.Fprintf(w, `<rss version="2.0"><channel><title>Feed</title>
+<item><guid>%d-a</guid><link>http://example.com/a</link></item>
+<item><guid>%d-b</guid><link>http://example.com/b</link></item>
+</channel></rss>`, fetches, fetches)
+	}))
+	defer server.Close()
+
+	store := gofeed.NewMemoryStore()
+	fp := gofeed.NewParser()
+	_, items, err := fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	assert.Len(t, items, 2)
+
+	_, items, err = fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	assert.Empty(t, items)
+
+	state, _ := store.Get(server.URL)
+	assert.Equal(t, []string{"guid:2-a", "guid:2-b"}, state.SeenItems)
+	assert.Equal(t, []string{"link:http://example.com/a", "link:http://example.com/b"}, state.SeenFallbacks)
+}
+
+func TestParser_ParseURLWithStore_StableGUIDs(t *testing.T) {
+	link := "a"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<rss version="2.0"><channel><title>Feed</title>
+<item><guid>1</guid><link>http://example.com/%s</link></item>
+<item><guid>2</guid><link>http://example.com/b</link></item>
+</channel></rss>`, link)
+	}))
+	defer server.Close()
+
+	store := gofeed.NewMemoryStore()
+	fp := gofeed.NewParser()
+	_, items, err := fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	assert.Len(t, items, 2)
+
+	link = "moved"
+	_, items, err = fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	assert.Empty(t, items)
+}
+
+func TestParser_ParseURLWithStore_Returning(t *testing.T) {
+	guids := []string{"1", "2"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `<rss version="2.0"><channel><title>Feed</title>`)
+		for _, guid := range guids {
+			fmt.Fprintf(w, `<item><guid>%s</guid></item>`, guid)
+		}
+		io.WriteString(w, `</channel></rss>`)
+	}))
+	defer server.Close()
+
+	store := gofeed.NewMemoryStore()
+	fp := gofeed.NewParser()
+	_, items, err := fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	assert.Len(t, items, 2)
+
+	guids = []string{"2"}
+	_, items, err = fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	assert.Empty(t, items)
+
+	guids = []string{"3", "1", "2"}
+	_, items, err = fp.ParseURLWithStore(server.URL, store)
+	require.Nil(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "3", items[0].GUID)
+
+	state, _ := store.Get(server.URL)
+	assert.Equal(t, []string{"guid:3", "guid:1", "guid:2"}, state.SeenItems)
+	assert.Len(t, state.SeenFallbacks, 3)
+}
+
+func TestParser_ParseURL_NotModified(t *testing.T) {
+	server, client := mockServerResponse(http.StatusNotModified, "", 0)
+	defer server.Close()
+
+	fp := gofeed.NewParser()
+	fp.Client = client
+	_, err := fp.ParseURL(server.URL)
+	assert.IsType(t, gofeed.HTTPError{}, err)
+}
@@ -2,6 +2,7 @@ package gofeed
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +41,7 @@ func (t *DefaultRSSTranslator) Translate(feed interface{}) (*Feed, error) {
 	result.Link = t.translateFeedLink(rss)
 	result.FeedLink = t.translateFeedFeedLink(rss)
 	result.Links = t.translateFeedLinks(rss)
+	result.Hubs = t.translateFeedHubs(rss)
 	result.Updated = t.translateFeedUpdated(rss)
 	result.UpdatedParsed = t.translateFeedUpdatedParsed(rss)
 	result.Published = t.translateFeedPublished(rss)
@@ -51,9 +53,14 @@ func (t *DefaultRSSTranslator) Translate(feed interface{}) (*Feed, error) {
 	result.Copyright = t.translateFeedCopyright(rss)
 	result.Generator = t.translateFeedGenerator(rss)
 	result.Categories = t.translateFeedCategories(rss)
+	result.RefreshInterval = t.translateFeedRefreshInterval(rss)
 	result.Items = t.translateFeedItems(rss)
 	result.ITunesExt = rss.ITunesExt
+	result.PodcastExt = rss.PodcastExt
+	result.MediaExt = rss.MediaExt
+	result.SyndicationExt = rss.SyndicationExt
 	result.DublinCoreExt = rss.DublinCoreExt
+	result.DublinCoreTermsExt = rss.DublinCoreTermsExt
 	result.Extensions = rss.Extensions
 	result.FeedVersion = rss.Version
 	result.FeedType = "rss"
@@ -67,6 +74,8 @@ func (t *DefaultRSSTranslator) translateFeedItem(rssItem *rss.Item) (item *Item)
 	item.Content = t.translateItemContent(rssItem)
 	item.Link = t.translateItemLink(rssItem)
 	item.Links = t.translateItemLinks(rssItem)
+	item.Updated = t.translateItemUpdated(rssItem)
+	item.UpdatedParsed = t.translateItemUpdatedParsed(rssItem)
 	item.Published = t.translateItemPublished(rssItem)
 	item.PublishedParsed = t.translateItemPublishedParsed(rssItem)
 	item.Author = t.translateItemAuthor(rssItem)
@@ -75,8 +84,18 @@ func (t *DefaultRSSTranslator) translateFeedItem(rssItem *rss.Item) (item *Item)
 	item.Image = t.translateItemImage(rssItem)
 	item.Categories = t.translateItemCategories(rssItem)
 	item.Enclosures = t.translateItemEnclosures(rssItem)
+	item.Source = t.translateItemSource(rssItem)
+	item.Location = t.translateItemLocation(rssItem)
+	item.License = t.translateItemLicense(rssItem)
+	item.CommentsLink = t.translateItemCommentsLink(rssItem)
+	item.CommentsFeed = t.translateItemCommentsFeed(rssItem)
+	item.CommentCount = t.translateItemCommentCount(rssItem)
+	item.InReplyTo = t.translateItemInReplyTo(rssItem)
 	item.DublinCoreExt = rssItem.DublinCoreExt
+	item.DublinCoreTermsExt = rssItem.DublinCoreTermsExt
 	item.ITunesExt = rssItem.ITunesExt
+	item.PodcastExt = rssItem.PodcastExt
+	item.MediaExt = rssItem.MediaExt
 	item.Extensions = rssItem.Extensions
 	item.Custom = rssItem.Custom
 	return
@@ -138,11 +157,25 @@ func (t *DefaultRSSTranslator) translateFeedLinks(rss *rss.Feed) (links []string
 	return
 }
 
+func (t *DefaultRSSTranslator) translateFeedHubs(rss *rss.Feed) (hubs []string) {
+	atomExtensions := t.extensionsForKeys([]string{"atom", "atom10", "atom03"}, rss.Extensions)
+	for _, ex := range atomExtensions {
+		for _, l := range ex["link"] {
+			if l.Attrs["rel"] == "hub" && l.Attrs["href"] != "" {
+				hubs = appendUnique(hubs, l.Attrs["href"])
+			}
+		}
+	}
+	return
+}
+
 func (t *DefaultRSSTranslator) translateFeedUpdated(rss *rss.Feed) (updated string) {
 	if rss.LastBuildDate != "" {
 		updated = rss.LastBuildDate
 	} else if rss.DublinCoreExt != nil && rss.DublinCoreExt.Date != nil {
 		updated = t.firstEntry(rss.DublinCoreExt.Date)
+	} else if rss.DublinCoreTermsExt != nil && rss.DublinCoreTermsExt.Modified != nil {
+		updated = t.firstEntry(rss.DublinCoreTermsExt.Modified)
 	}
 	return
 }
@@ -156,16 +189,28 @@ func (t *DefaultRSSTranslator) translateFeedUpdatedParsed(rss *rss.Feed) (update
 		if err == nil {
 			updated = &date
 		}
+	} else if rss.DublinCoreTermsExt != nil && rss.DublinCoreTermsExt.Modified != nil {
+		updated = parseFirstDate(rss.DublinCoreTermsExt.Modified)
 	}
 	return
 }
 
 func (t *DefaultRSSTranslator) translateFeedPublished(rss *rss.Feed) (published string) {
-	return rss.PubDate
+	if rss.PubDate != "" {
+		published = rss.PubDate
+	} else if rss.DublinCoreTermsExt != nil && rss.DublinCoreTermsExt.Issued != nil {
+		published = t.firstEntry(rss.DublinCoreTermsExt.Issued)
+	}
+	return
 }
 
 func (t *DefaultRSSTranslator) translateFeedPublishedParsed(rss *rss.Feed) (published *time.Time) {
-	return rss.PubDateParsed
+	if rss.PubDateParsed != nil {
+		published = rss.PubDateParsed
+	} else if rss.DublinCoreTermsExt != nil && rss.DublinCoreTermsExt.Issued != nil {
+		published = parseFirstDate(rss.DublinCoreTermsExt.Issued)
+	}
+	return
 }
 
 func (t *DefaultRSSTranslator) translateFeedAuthor(rss *rss.Feed) (author *Person) {
@@ -241,6 +286,22 @@ func (t *DefaultRSSTranslator) translateFeedGenerator(rss *rss.Feed) (generator
 	return rss.Generator
 }
 
+// translateFeedRefreshInterval returns the longer of the ttl
+// and the update interval of the syndication extension, as
+// polling more often than either is unlikely to find changes.
+func (t *DefaultRSSTranslator) translateFeedRefreshInterval(rss *rss.Feed) (interval time.Duration) {
+	if ttl, err := strconv.Atoi(strings.TrimSpace(rss.TTL)); err == nil && ttl > 0 {
+		interval = time.Duration(ttl) * time.Minute
+	}
+
+	if rss.SyndicationExt != nil {
+		if sy := rss.SyndicationExt.Interval(); sy > interval {
+			interval = sy
+		}
+	}
+	return
+}
+
 func (t *DefaultRSSTranslator) translateFeedCategories(rss *rss.Feed) (categories []string) {
 	cats := []string{}
 	if rss.Categories != nil {
@@ -280,12 +341,22 @@ func (t *DefaultRSSTranslator) translateFeedCategories(rss *rss.Feed) (categorie
 
 func (t *DefaultRSSTranslator) translateFeedItems(rss *rss.Feed) (items []*Item) {
 	items = []*Item{}
+	license := t.translateFeedLicense(rss)
 	for _, i := range rss.Items {
-		items = append(items, t.translateFeedItem(i))
+		item := t.translateFeedItem(i)
+		item.License = inheritLicense(item.License, license)
+		items = append(items, item)
 	}
 	return
 }
 
+// translateFeedLicense returns the license declared at the
+// channel level, which applies to every item without a
+// license of its own.
+func (t *DefaultRSSTranslator) translateFeedLicense(rss *rss.Feed) (license *License) {
+	return newLicense(parseLicenseURL(rss.Extensions), "")
+}
+
 func (t *DefaultRSSTranslator) translateItemTitle(rssItem *rss.Item) (title string) {
 	if rssItem.Title != "" {
 		title = rssItem.Title
@@ -319,19 +390,15 @@ func (t *DefaultRSSTranslator) translateItemLinks(rssItem *rss.Item) (links []st
 }
 
 func (t *DefaultRSSTranslator) translateItemUpdated(rssItem *rss.Item) (updated string) {
-	if rssItem.DublinCoreExt != nil && rssItem.DublinCoreExt.Date != nil {
-		updated = t.firstEntry(rssItem.DublinCoreExt.Date)
+	if rssItem.DublinCoreTermsExt != nil && rssItem.DublinCoreTermsExt.Modified != nil {
+		updated = t.firstEntry(rssItem.DublinCoreTermsExt.Modified)
 	}
 	return updated
 }
 
 func (t *DefaultRSSTranslator) translateItemUpdatedParsed(rssItem *rss.Item) (updated *time.Time) {
-	if rssItem.DublinCoreExt != nil && rssItem.DublinCoreExt.Date != nil {
-		updatedText := t.firstEntry(rssItem.DublinCoreExt.Date)
-		updatedDate, err := shared.ParseDate(updatedText)
-		if err == nil {
-			updated = &updatedDate
-		}
+	if rssItem.DublinCoreTermsExt != nil && rssItem.DublinCoreTermsExt.Modified != nil {
+		updated = parseFirstDate(rssItem.DublinCoreTermsExt.Modified)
 	}
 	return
 }
@@ -341,6 +408,8 @@ func (t *DefaultRSSTranslator) translateItemPublished(rssItem *rss.Item) (pubDat
 		return rssItem.PubDate
 	} else if rssItem.DublinCoreExt != nil && rssItem.DublinCoreExt.Date != nil {
 		return t.firstEntry(rssItem.DublinCoreExt.Date)
+	} else if rssItem.DublinCoreTermsExt != nil && rssItem.DublinCoreTermsExt.Issued != nil {
+		return t.firstEntry(rssItem.DublinCoreTermsExt.Issued)
 	}
 	return
 }
@@ -354,6 +423,8 @@ func (t *DefaultRSSTranslator) translateItemPublishedParsed(rssItem *rss.Item) (
 		if err == nil {
 			pubDate = &pubDateParsed
 		}
+	} else if rssItem.DublinCoreTermsExt != nil && rssItem.DublinCoreTermsExt.Issued != nil {
+		pubDate = parseFirstDate(rssItem.DublinCoreTermsExt.Issued)
 	}
 	return
 }
@@ -404,6 +475,8 @@ func (t *DefaultRSSTranslator) translateItemImage(rssItem *rss.Item) (image *Ima
 	if rssItem.ITunesExt != nil && rssItem.ITunesExt.Image != "" {
 		image = &Image{}
 		image.URL = rssItem.ITunesExt.Image
+	} else {
+		image = mediaImage(rssItem.MediaExt)
 	}
 	return
 }
@@ -444,6 +517,44 @@ func (t *DefaultRSSTranslator) translateItemEnclosures(rssItem *rss.Item) (enclo
 		e.Length = rssItem.Enclosure.Length
 		enclosures = []*Enclosure{e}
 	}
+	enclosures = appendMediaEnclosures(enclosures, rssItem.MediaExt)
+	return
+}
+
+func (t *DefaultRSSTranslator) translateItemLocation(rssItem *rss.Item) (location *Location) {
+	return parseLocation(rssItem.Extensions)
+}
+
+func (t *DefaultRSSTranslator) translateItemLicense(rssItem *rss.Item) (license *License) {
+	rights := ""
+	if rssItem.DublinCoreExt != nil {
+		rights = t.firstEntry(rssItem.DublinCoreExt.Rights)
+	}
+	return newLicense(parseLicenseURL(rssItem.Extensions), rights)
+}
+
+func (t *DefaultRSSTranslator) translateItemCommentsLink(rssItem *rss.Item) (link string) {
+	return strings.TrimSpace(rssItem.Comments)
+}
+
+func (t *DefaultRSSTranslator) translateItemCommentsFeed(rssItem *rss.Item) (link string) {
+	return parseCommentsFeed(rssItem.Extensions)
+}
+
+func (t *DefaultRSSTranslator) translateItemCommentCount(rssItem *rss.Item) (count *int) {
+	return parseCommentCount(rssItem.Extensions)
+}
+
+func (t *DefaultRSSTranslator) translateItemInReplyTo(rssItem *rss.Item) (replies []*InReplyTo) {
+	return parseInReplyTo(rssItem.Extensions)
+}
+
+func (t *DefaultRSSTranslator) translateItemSource(rssItem *rss.Item) (source *Source) {
+	if rssItem.Source != nil {
+		source = &Source{}
+		source.Title = rssItem.Source.Title
+		source.FeedLink = rssItem.Source.URL
+	}
 	return
 }
 
@@ -496,8 +607,11 @@ func (t *DefaultAtomTranslator) Translate(feed interface{}) (*Feed, error) {
 	result.Link = t.translateFeedLink(atom)
 	result.FeedLink = t.translateFeedFeedLink(atom)
 	result.Links = t.translateFeedLinks(atom)
+	result.Hubs = t.translateFeedHubs(atom)
 	result.Updated = t.translateFeedUpdated(atom)
 	result.UpdatedParsed = t.translateFeedUpdatedParsed(atom)
+	result.Published = t.translateFeedPublished(atom)
+	result.PublishedParsed = t.translateFeedPublishedParsed(atom)
 	result.Author = t.translateFeedAuthor(atom)
 	result.Authors = t.translateFeedAuthors(atom)
 	result.Language = t.translateFeedLanguage(atom)
@@ -506,6 +620,10 @@ func (t *DefaultAtomTranslator) Translate(feed interface{}) (*Feed, error) {
 	result.Categories = t.translateFeedCategories(atom)
 	result.Generator = t.translateFeedGenerator(atom)
 	result.Items = t.translateFeedItems(atom)
+	result.MediaExt = t.translateFeedMediaExt(atom)
+	result.SyndicationExt = t.translateFeedSyndicationExt(atom)
+	result.DublinCoreTermsExt = t.translateFeedDublinCoreTermsExt(atom)
+	result.RefreshInterval = t.translateFeedRefreshInterval(atom)
 	result.Extensions = atom.Extensions
 	result.FeedVersion = atom.Version
 	result.FeedType = "atom"
@@ -529,6 +647,15 @@ func (t *DefaultAtomTranslator) translateFeedItem(entry *atom.Entry) (item *Item
 	item.Image = t.translateItemImage(entry)
 	item.Categories = t.translateItemCategories(entry)
 	item.Enclosures = t.translateItemEnclosures(entry)
+	item.Source = t.translateItemSource(entry)
+	item.Location = t.translateItemLocation(entry)
+	item.License = t.translateItemLicense(entry)
+	item.CommentsLink = t.translateItemCommentsLink(entry)
+	item.CommentsFeed = t.translateItemCommentsFeed(entry)
+	item.CommentCount = t.translateItemCommentCount(entry)
+	item.InReplyTo = t.translateItemInReplyTo(entry)
+	item.MediaExt = t.translateItemMediaExt(entry)
+	item.DublinCoreTermsExt = t.translateItemDublinCoreTermsExt(entry)
 	item.Extensions = entry.Extensions
 	return
 }
@@ -566,12 +693,47 @@ func (t *DefaultAtomTranslator) translateFeedLinks(atom *atom.Feed) (links []str
 	return
 }
 
+func (t *DefaultAtomTranslator) translateFeedHubs(atom *atom.Feed) (hubs []string) {
+	for _, l := range atom.Links {
+		if l.Rel == "hub" && l.Href != "" {
+			hubs = appendUnique(hubs, l.Href)
+		}
+	}
+	return
+}
+
 func (t *DefaultAtomTranslator) translateFeedUpdated(atom *atom.Feed) (updated string) {
-	return atom.Updated
+	updated = atom.Updated
+	if updated == "" {
+		if dcterms := t.translateFeedDublinCoreTermsExt(atom); dcterms != nil && dcterms.Modified != nil {
+			updated = dcterms.Modified[0]
+		}
+	}
+	return
 }
 
 func (t *DefaultAtomTranslator) translateFeedUpdatedParsed(atom *atom.Feed) (updated *time.Time) {
-	return atom.UpdatedParsed
+	updated = atom.UpdatedParsed
+	if updated == nil {
+		if dcterms := t.translateFeedDublinCoreTermsExt(atom); dcterms != nil && dcterms.Modified != nil {
+			updated = parseFirstDate(dcterms.Modified)
+		}
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateFeedPublished(atom *atom.Feed) (published string) {
+	if dcterms := t.translateFeedDublinCoreTermsExt(atom); dcterms != nil && dcterms.Issued != nil {
+		published = dcterms.Issued[0]
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateFeedPublishedParsed(atom *atom.Feed) (published *time.Time) {
+	if dcterms := t.translateFeedDublinCoreTermsExt(atom); dcterms != nil && dcterms.Issued != nil {
+		published = parseFirstDate(dcterms.Issued)
+	}
+	return
 }
 
 func (t *DefaultAtomTranslator) translateFeedAuthor(atom *atom.Feed) (author *Person) {
@@ -646,12 +808,25 @@ func (t *DefaultAtomTranslator) translateFeedCategories(atom *atom.Feed) (catego
 
 func (t *DefaultAtomTranslator) translateFeedItems(atom *atom.Feed) (items []*Item) {
 	items = []*Item{}
+	license := t.translateFeedLicense(atom)
 	for _, entry := range atom.Entries {
-		items = append(items, t.translateFeedItem(entry))
+		item := t.translateFeedItem(entry)
+		item.License = inheritLicense(item.License, license)
+		items = append(items, item)
 	}
 	return
 }
 
+// translateFeedLicense returns the license and rights of the
+// feed, which apply to every entry without its own.
+func (t *DefaultAtomTranslator) translateFeedLicense(atom *atom.Feed) (license *License) {
+	licenseURL := parseLicenseURL(atom.Extensions)
+	if l := t.firstLinkWithType("license", atom.Links); l != nil {
+		licenseURL = l.Href
+	}
+	return newLicense(licenseURL, atom.Rights)
+}
+
 func (t *DefaultAtomTranslator) translateItemTitle(entry *atom.Entry) (title string) {
 	return entry.Title
 }
@@ -685,17 +860,34 @@ func (t *DefaultAtomTranslator) translateItemLinks(entry *atom.Entry) (links []s
 }
 
 func (t *DefaultAtomTranslator) translateItemUpdated(entry *atom.Entry) (updated string) {
-	return entry.Updated
+	updated = entry.Updated
+	if updated == "" {
+		if dcterms := t.translateItemDublinCoreTermsExt(entry); dcterms != nil && dcterms.Modified != nil {
+			updated = dcterms.Modified[0]
+		}
+	}
+	return
 }
 
 func (t *DefaultAtomTranslator) translateItemUpdatedParsed(entry *atom.Entry) (updated *time.Time) {
-	return entry.UpdatedParsed
+	updated = entry.UpdatedParsed
+	if updated == nil {
+		if dcterms := t.translateItemDublinCoreTermsExt(entry); dcterms != nil && dcterms.Modified != nil {
+			updated = parseFirstDate(dcterms.Modified)
+		}
+	}
+	return
 }
 
 func (t *DefaultAtomTranslator) translateItemPublished(entry *atom.Entry) (published string) {
 	published = entry.Published
 	if published == "" {
-		published = entry.Updated
+		if dcterms := t.translateItemDublinCoreTermsExt(entry); dcterms != nil && dcterms.Issued != nil {
+			published = dcterms.Issued[0]
+		}
+	}
+	if published == "" {
+		published = t.translateItemUpdated(entry)
 	}
 	return
 }
@@ -703,7 +895,12 @@ func (t *DefaultAtomTranslator) translateItemPublished(entry *atom.Entry) (publi
 func (t *DefaultAtomTranslator) translateItemPublishedParsed(entry *atom.Entry) (published *time.Time) {
 	published = entry.PublishedParsed
 	if published == nil {
-		published = entry.UpdatedParsed
+		if dcterms := t.translateItemDublinCoreTermsExt(entry); dcterms != nil && dcterms.Issued != nil {
+			published = parseFirstDate(dcterms.Issued)
+		}
+	}
+	if published == nil {
+		published = t.translateItemUpdatedParsed(entry)
 	}
 	return
 }
@@ -736,7 +933,7 @@ func (t *DefaultAtomTranslator) translateItemGUID(entry *atom.Entry) (guid strin
 }
 
 func (t *DefaultAtomTranslator) translateItemImage(entry *atom.Entry) (image *Image) {
-	return nil
+	return mediaImage(t.translateItemMediaExt(entry))
 }
 
 func (t *DefaultAtomTranslator) translateItemCategories(entry *atom.Entry) (categories []string) {
@@ -766,6 +963,103 @@ func (t *DefaultAtomTranslator) translateItemEnclosures(entry *atom.Entry) (encl
 			enclosures = nil
 		}
 	}
+	enclosures = appendMediaEnclosures(enclosures, t.translateItemMediaExt(entry))
+	return
+}
+
+func (t *DefaultAtomTranslator) translateItemLocation(entry *atom.Entry) (location *Location) {
+	return parseLocation(entry.Extensions)
+}
+
+func (t *DefaultAtomTranslator) translateItemLicense(entry *atom.Entry) (license *License) {
+	licenseURL := parseLicenseURL(entry.Extensions)
+	if l := t.firstLinkWithType("license", entry.Links); l != nil {
+		licenseURL = l.Href
+	}
+	return newLicense(licenseURL, entry.Rights)
+}
+
+// translateItemCommentsLink returns the first replies link to
+// an html page, as opposed to a feed of the replies.
+func (t *DefaultAtomTranslator) translateItemCommentsLink(entry *atom.Entry) (link string) {
+	for _, l := range entry.Links {
+		if l.Rel == "replies" && (l.Type == "text/html" || l.Type == "application/xhtml+xml") {
+			return l.Href
+		}
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateItemCommentsFeed(entry *atom.Entry) (link string) {
+	for _, l := range entry.Links {
+		if l.Rel == "replies" && l.Type != "text/html" && l.Type != "application/xhtml+xml" {
+			return l.Href
+		}
+	}
+	return parseCommentsFeed(entry.Extensions)
+}
+
+func (t *DefaultAtomTranslator) translateItemCommentCount(entry *atom.Entry) (count *int) {
+	return parseCommentCount(entry.Extensions)
+}
+
+func (t *DefaultAtomTranslator) translateItemInReplyTo(entry *atom.Entry) (replies []*InReplyTo) {
+	return parseInReplyTo(entry.Extensions)
+}
+
+func (t *DefaultAtomTranslator) translateItemSource(entry *atom.Entry) (source *Source) {
+	if entry.Source != nil {
+		source = &Source{}
+		source.Title = entry.Source.Title
+		if l := t.firstLinkWithType("alternate", entry.Source.Links); l != nil {
+			source.Link = l.Href
+		}
+		if l := t.firstLinkWithType("self", entry.Source.Links); l != nil {
+			source.FeedLink = l.Href
+		}
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateFeedDublinCoreTermsExt(atom *atom.Feed) (dcterms *ext.DublinCoreTermsExtension) {
+	if d, ok := atom.Extensions["dcterms"]; ok {
+		dcterms = ext.NewDublinCoreTermsExtension(d)
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateItemDublinCoreTermsExt(entry *atom.Entry) (dcterms *ext.DublinCoreTermsExtension) {
+	if d, ok := entry.Extensions["dcterms"]; ok {
+		dcterms = ext.NewDublinCoreTermsExtension(d)
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateFeedSyndicationExt(atom *atom.Feed) (sy *ext.SyndicationExtension) {
+	if s, ok := atom.Extensions["sy"]; ok {
+		sy = ext.NewSyndicationExtension(s)
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateFeedRefreshInterval(atom *atom.Feed) (interval time.Duration) {
+	if sy := t.translateFeedSyndicationExt(atom); sy != nil {
+		interval = sy.Interval()
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateFeedMediaExt(atom *atom.Feed) (media *ext.MediaExtension) {
+	if m, ok := atom.Extensions["media"]; ok {
+		media = ext.NewMediaExtension(m)
+	}
+	return
+}
+
+func (t *DefaultAtomTranslator) translateItemMediaExt(entry *atom.Entry) (media *ext.MediaExtension) {
+	if m, ok := entry.Extensions["media"]; ok {
+		media = ext.NewMediaExtension(m)
+	}
 	return
 }
 
@@ -791,6 +1085,70 @@ func (t *DefaultAtomTranslator) firstPerson(persons []*atom.Person) (person *ato
 	return
 }
 
+// parseFirstDate parses the first of the dates, returning
+// nil if there are none or it is not a recognized date.
+func parseFirstDate(dates []string) *time.Time {
+	if len(dates) == 0 {
+		return nil
+	}
+	date, err := shared.ParseDate(dates[0])
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+// mediaImage returns the image of a Media RSS extension,
+// used when the item has no image of its own.
+func mediaImage(media *ext.MediaExtension) (image *Image) {
+	if media == nil {
+		return
+	}
+	if url := media.Image(); url != "" {
+		image = &Image{}
+		image.URL = url
+	}
+	return
+}
+
+// appendMediaEnclosures adds the media objects of a Media
+// RSS extension to the enclosures, skipping any which are
+// already enclosed.
+func appendMediaEnclosures(enclosures []*Enclosure, media *ext.MediaExtension) []*Enclosure {
+	if media == nil {
+		return enclosures
+	}
+
+	seen := map[string]bool{}
+	for _, e := range enclosures {
+		seen[e.URL] = true
+	}
+
+	for _, c := range media.AllContents() {
+		if c.URL == "" || seen[c.URL] {
+			continue
+		}
+		seen[c.URL] = true
+		e := &Enclosure{}
+		e.URL = c.URL
+		e.Type = c.Type
+		e.Length = c.FileSize
+		enclosures = append(enclosures, e)
+	}
+	return enclosures
+}
+
+// appendUnique adds the value to the values unless it is
+// already one of them.
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
 // DefaultJSONTranslator converts an json.Feed struct
 // into the generic Feed struct.
 //
@@ -813,6 +1171,7 @@ func (t *DefaultJSONTranslator) Translate(feed interface{}) (*Feed, error) {
 	result.Link = t.translateFeedLink(json)
 	result.FeedLink = t.translateFeedFeedLink(json)
 	result.Links = t.translateFeedLinks(json)
+	result.Hubs = t.translateFeedHubs(json)
 	result.Description = t.translateFeedDescription(json)
 	result.Image = t.translateFeedImage(json)
 	result.Author = t.translateFeedAuthor(json)
@@ -823,13 +1182,12 @@ func (t *DefaultJSONTranslator) Translate(feed interface{}) (*Feed, error) {
 	result.UpdatedParsed = t.translateFeedUpdatedParsed(json)
 	result.Published = t.translateFeedPublished(json)
 	result.PublishedParsed = t.translateFeedPublishedParsed(json)
+	result.Extensions = t.translateFeedExtensions(json)
 	result.FeedType = "json"
 	// TODO UserComment is missing in global Feed
 	// TODO NextURL is missing in global Feed
 	// TODO Favicon is missing in global Feed
 	// TODO Exipred is missing in global Feed
-	// TODO Hubs is not supported in json.Feed
-	// TODO Extensions is not supported in json.Feed
 	return result, nil
 }
 
@@ -850,6 +1208,7 @@ func (t *DefaultJSONTranslator) translateFeedItem(jsonItem *json.Item) (item *It
 	item.Authors = t.translateItemAuthors(jsonItem)
 	item.Categories = t.translateItemCategories(jsonItem)
 	item.Enclosures = t.translateItemEnclosures(jsonItem)
+	item.Extensions = t.translateItemExtensions(jsonItem)
 	// TODO ExternalURL is missing in global Feed
 	// TODO BannerImage is missing in global Feed
 	return
@@ -890,6 +1249,21 @@ func (t *DefaultJSONTranslator) translateFeedLinks(json *json.Feed) (links []str
 	return
 }
 
+// translateFeedHubs returns the WebSub hubs of the feed.  Hubs
+// of other types, such as rssCloud, are not WebSub hubs.
+func (t *DefaultJSONTranslator) translateFeedHubs(json *json.Feed) (hubs []string) {
+	for _, h := range json.Hubs {
+		if h == nil || h.URL == "" {
+			continue
+		}
+		switch strings.ToLower(h.Type) {
+		case "websub", "pubsubhubbub", "pubsub":
+			hubs = appendUnique(hubs, h.URL)
+		}
+	}
+	return
+}
+
 func (t *DefaultJSONTranslator) translateFeedUpdated(json *json.Feed) (updated string) {
 	if len(json.Items) > 0 {
 		updated = json.Items[0].DateModified
@@ -971,6 +1345,10 @@ func (t *DefaultJSONTranslator) translateFeedImage(json *json.Feed) (image *Imag
 	return
 }
 
+func (t *DefaultJSONTranslator) translateFeedExtensions(json *json.Feed) (extensions ext.Extensions) {
+	return json.ExtensionElements()
+}
+
 func (t *DefaultJSONTranslator) translateFeedItems(json *json.Feed) (items []*Item) {
 	items = []*Item{}
 	for _, i := range json.Items {
@@ -1082,6 +1460,10 @@ func (t *DefaultJSONTranslator) translateItemAuthors(jsonItem *json.Item) (autho
 	return
 }
 
+func (t *DefaultJSONTranslator) translateItemExtensions(jsonItem *json.Item) (extensions ext.Extensions) {
+	return jsonItem.ExtensionElements()
+}
+
 func (t *DefaultJSONTranslator) translateItemGUID(jsonItem *json.Item) (guid string) {
 	if jsonItem.ID != "" {
 		guid = jsonItem.ID
@@ -1109,7 +1491,7 @@ func (t *DefaultJSONTranslator) translateItemCategories(jsonItem *json.Item) (ca
 
 func (t *DefaultJSONTranslator) translateItemEnclosures(jsonItem *json.Item) (enclosures []*Enclosure) {
 	if jsonItem.Attachments != nil {
-		for _, attachment := range *jsonItem.Attachments {
+		for _, attachment := range jsonItem.Attachments {
 			e := &Enclosure{}
 			e.URL = attachment.URL
 			e.Type = attachment.MimeType
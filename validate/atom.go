@@ -0,0 +1,144 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/mmcdole/gofeed/atom"
+)
+
+// Atom checks an atom.Feed against the Atom 1.0
+// specification (RFC 4287).
+func Atom(feed *atom.Feed) Findings {
+	c := &checker{findings: Findings{}}
+	if feed == nil {
+		c.add(Error, RuleRequired, "feed", "feed is required")
+		return c.findings
+	}
+
+	path := "feed"
+	if c.required(path+"/id", feed.ID) {
+		c.url(Warning, path+"/id", feed.ID)
+	}
+	c.required(path+"/title", feed.Title)
+	if c.required(path+"/updated", feed.Updated) {
+		c.rfc3339(path+"/updated", feed.Updated)
+	}
+	c.url(Warning, path+"/icon", feed.Icon)
+	c.url(Warning, path+"/logo", feed.Logo)
+	c.atomLinks(path, feed.Links)
+	c.atomPersons(path+"/author", feed.Authors)
+	c.atomPersons(path+"/contributor", feed.Contributors)
+	c.atomCategories(path, feed.Categories)
+
+	if !hasLink(feed.Links, "self") {
+		c.add(Info, RuleRequired, path+"/link", "a link with rel=\"self\" is recommended")
+	}
+
+	ids := map[string]string{}
+	for i, entry := range feed.Entries {
+		if entry == nil {
+			continue
+		}
+		entryPath := index(path+"/entry", i)
+		c.atomEntry(entryPath, entry, ids)
+
+		if len(feed.Authors) == 0 && len(entry.Authors) == 0 &&
+			(entry.Source == nil || len(entry.Source.Authors) == 0) {
+			c.add(Error, RuleRequired, entryPath+"/author", "author is required when the feed has no author")
+		}
+	}
+	return c.findings
+}
+
+func (c *checker) atomEntry(path string, entry *atom.Entry, ids map[string]string) {
+	if c.required(path+"/id", entry.ID) {
+		c.url(Warning, path+"/id", entry.ID)
+	}
+	c.unique(ids, path+"/id", entry.ID)
+	c.required(path+"/title", entry.Title)
+	if c.required(path+"/updated", entry.Updated) {
+		c.rfc3339(path+"/updated", entry.Updated)
+	}
+	c.rfc3339(path+"/published", entry.Published)
+	c.atomLinks(path, entry.Links)
+	c.atomPersons(path+"/author", entry.Authors)
+	c.atomPersons(path+"/contributor", entry.Contributors)
+	c.atomCategories(path, entry.Categories)
+
+	if entry.Content == nil && !hasLink(entry.Links, "alternate") {
+		c.add(Error, RuleRequired, path+"/link", "entry without content must have a link with rel=\"alternate\"")
+	}
+
+	if entry.Content != nil && entry.Summary == "" {
+		if entry.Content.Src != "" {
+			c.add(Error, RuleRequired, path+"/summary", "summary is required when content has a src")
+		} else if t := entry.Content.Type; t != "" && t != "text" && t != "html" && t != "xhtml" &&
+			!strings.HasPrefix(t, "text/") && !isXMLType(t) {
+			c.add(Error, RuleRequired, path+"/summary", "summary is required when content is base64 encoded")
+		}
+	}
+
+	if entry.Content != nil {
+		c.url(Error, path+"/content@src", entry.Content.Src)
+	}
+
+	if entry.Source != nil {
+		c.url(Warning, path+"/source/id", entry.Source.ID)
+		c.rfc3339(path+"/source/updated", entry.Source.Updated)
+		c.atomLinks(path+"/source", entry.Source.Links)
+	}
+}
+
+func (c *checker) atomLinks(path string, links []*atom.Link) {
+	for i, link := range links {
+		if link == nil {
+			continue
+		}
+		linkPath := index(path+"/link", i)
+		if c.required(linkPath+"@href", link.Href) {
+			c.url(Warning, linkPath+"@href", link.Href)
+		}
+		if link.Rel == "enclosure" {
+			c.length(linkPath+"@length", link.Length)
+			c.mimeType(linkPath+"@type", link.Type)
+		}
+	}
+}
+
+func (c *checker) atomPersons(path string, persons []*atom.Person) {
+	for i, person := range persons {
+		if person == nil {
+			continue
+		}
+		personPath := index(path, i)
+		c.required(personPath+"/name", person.Name)
+		c.url(Warning, personPath+"/uri", person.URI)
+		if person.Email != "" && !strings.Contains(person.Email, "@") {
+			c.add(Error, RuleValue, personPath+"/email", "%q is not an email address", person.Email)
+		}
+	}
+}
+
+func (c *checker) atomCategories(path string, categories []*atom.Category) {
+	for i, category := range categories {
+		if category != nil {
+			c.required(index(path+"/category", i)+"@term", category.Term)
+		}
+	}
+}
+
+func hasLink(links []*atom.Link, rel string) bool {
+	for _, link := range links {
+		if link == nil {
+			continue
+		}
+		if link.Rel == rel || (rel == "alternate" && link.Rel == "") {
+			return true
+		}
+	}
+	return false
+}
+
+func isXMLType(t string) bool {
+	return strings.HasSuffix(t, "+xml") || strings.HasSuffix(t, "/xml")
+}
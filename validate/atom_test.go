@@ -0,0 +1,70 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/mmcdole/gofeed/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseAtom(t *testing.T, data string) *atom.Feed {
+	fp := &atom.Parser{}
+	feed, err := fp.Parse(strings.NewReader(data))
+	require.Nil(t, err)
+	return feed
+}
+
+func TestAtom_Valid(t *testing.T) {
+	feed := parseAtom(t, `<feed xmlns="http://www.w3.org/2005/Atom">
+<id>urn:uuid:60a76c80-d399-11d9-b93C-0003939e0af6</id>
+<title>Feed</title>
+<updated>2020-01-01T00:00:00Z</updated>
+<link rel="self" href="http://example.com/feed.atom"/>
+<author><name>Author</name></author>
+<entry>
+<id>http://example.com/1</id>
+<title>Entry</title>
+<updated>2020-01-01T00:00:00+01:00</updated>
+<link href="http://example.com/1"/>
+<link rel="enclosure" href="http://example.com/1.mp3" length="1024" type="audio/mpeg"/>
+</entry>
+</feed>`)
+
+	assert.Empty(t, validate.Atom(feed))
+}
+
+func TestAtom_Invalid(t *testing.T) {
+	feed := parseAtom(t, `<feed xmlns="http://www.w3.org/2005/Atom">
+<title>Feed</title>
+<updated>01 Jan 2020</updated>
+<entry>
+<id>http://example.com/1</id>
+<title>Entry</title>
+<updated>2020-01-01T00:00:00Z</updated>
+<content type="video/mp4">aGVsbG8=</content>
+</entry>
+<entry>
+<id>http://example.com/1</id>
+<title>Entry</title>
+<updated>2020-01-01T00:00:00Z</updated>
+<author><name>Author</name></author>
+<link rel="enclosure" href="http://example.com/1.mp3"/>
+</entry>
+</feed>`)
+
+	findings := validate.Atom(feed)
+	assert.Equal(t, map[string]validate.Severity{
+		"feed/id":                      validate.Error,
+		"feed/updated":                 validate.Error,
+		"feed/link":                    validate.Info,
+		"feed/entry[0]/summary":        validate.Error,
+		"feed/entry[0]/author":         validate.Error,
+		"feed/entry[1]/id":             validate.Warning,
+		"feed/entry[1]/link":           validate.Error,
+		"feed/entry[1]/link[0]@length": validate.Error,
+		"feed/entry[1]/link[0]@type":   validate.Error,
+	}, paths(findings))
+}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// itunes checks the elements that the iTunes podcast
+// specification requires of a podcast feed
+func (c *checker) itunes(channel string, feed *rss.Feed) {
+	itunes := feed.ITunesExt
+	if itunes == nil {
+		itunes = ext.NewITunesFeedExtension(feed.Extensions["itunes"])
+	}
+
+	if feed.Language == "" {
+		c.add(Error, RuleITunes, channel+"/language", "language is required for podcasts")
+	}
+	if itunes.Image == "" {
+		c.add(Error, RuleITunes, channel+"/itunes:image", "itunes:image is required for podcasts")
+	}
+	c.url(Error, channel+"/itunes:image@href", itunes.Image)
+	if len(itunes.Categories) == 0 {
+		c.add(Error, RuleITunes, channel+"/itunes:category", "itunes:category is required for podcasts")
+	}
+	if itunes.Explicit == "" {
+		c.add(Error, RuleITunes, channel+"/itunes:explicit", "itunes:explicit is required for podcasts")
+	}
+	if itunes.Author == "" {
+		c.add(Warning, RuleITunes, channel+"/itunes:author", "itunes:author is recommended for podcasts")
+	}
+	if itunes.Owner == nil || itunes.Owner.Email == "" {
+		c.add(Warning, RuleITunes, channel+"/itunes:owner/itunes:email", "itunes:owner email is recommended for podcasts")
+	}
+
+	items := channel + "/item"
+	for i, item := range feed.Items {
+		if item == nil {
+			continue
+		}
+		path := index(items, i)
+		if item.Title == "" {
+			c.add(Error, RuleITunes, path+"/title", "title is required for episodes")
+		}
+		if item.Enclosure == nil {
+			c.add(Error, RuleITunes, path+"/enclosure", "enclosure is required for episodes")
+		}
+		if item.GUID == nil {
+			c.add(Warning, RuleITunes, path+"/guid", "guid is recommended for episodes")
+		}
+	}
+}
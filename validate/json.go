@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/mmcdole/gofeed/json"
+)
+
+// JSON checks a json.Feed against the JSON Feed 1.0 and 1.1
+// specifications. Paths use the JSON keys of the feed, such as
+// "items[0].attachments[1].url".
+func JSON(feed *json.Feed) Findings {
+	c := &checker{findings: Findings{}}
+	if feed == nil {
+		c.add(Error, RuleRequired, "feed", "feed is required")
+		return c.findings
+	}
+
+	if feed.Version == "" {
+		c.add(Error, RuleRequired, "version", "element is required")
+	} else if feed.Version != json.Version10 && feed.Version != json.Version11 {
+		c.add(Error, RuleValue, "version", "%q is not a JSON Feed version URL", feed.Version)
+	}
+	c.required("title", feed.Title)
+
+	if feed.HomePageURL == "" {
+		c.add(Info, RuleRequired, "home_page_url", "home_page_url is strongly recommended")
+	}
+	c.url(Error, "home_page_url", feed.HomePageURL)
+	if feed.FeedURL == "" {
+		c.add(Info, RuleRequired, "feed_url", "feed_url is strongly recommended")
+	}
+	c.url(Error, "feed_url", feed.FeedURL)
+	c.url(Error, "next_url", feed.NextURL)
+	c.url(Error, "icon", feed.Icon)
+	c.url(Error, "favicon", feed.Favicon)
+	c.jsonAuthor("author", feed.Author)
+	for i, author := range feed.Authors {
+		c.jsonAuthor(index("authors", i), author)
+	}
+
+	ids := map[string]string{}
+	for i, item := range feed.Items {
+		if item != nil {
+			c.jsonItem(index("items", i), item, ids)
+		}
+	}
+	return c.findings
+}
+
+func (c *checker) jsonItem(path string, item *json.Item, ids map[string]string) {
+	c.required(path+".id", item.ID)
+	c.unique(ids, path+".id", item.ID)
+	if strings.TrimSpace(item.ContentHTML) == "" && strings.TrimSpace(item.ContentText) == "" {
+		c.add(Error, RuleRequired, path, "content_html or content_text is required")
+	}
+	c.url(Error, path+".url", item.URL)
+	c.url(Error, path+".external_url", item.ExternalURL)
+	c.url(Error, path+".image", item.Image)
+	c.url(Error, path+".banner_image", item.BannerImage)
+	c.rfc3339(path+".date_published", item.DatePublished)
+	c.rfc3339(path+".date_modified", item.DateModified)
+	c.jsonAuthor(path+".author", item.Author)
+	for i, author := range item.Authors {
+		c.jsonAuthor(index(path+".authors", i), author)
+	}
+
+	for i, attachment := range item.Attachments {
+		attachmentPath := index(path+".attachments", i)
+		if c.required(attachmentPath+".url", attachment.URL) {
+			c.url(Error, attachmentPath+".url", attachment.URL)
+		}
+		if c.required(attachmentPath+".mime_type", attachment.MimeType) {
+			c.mimeType(attachmentPath+".mime_type", attachment.MimeType)
+		}
+		if attachment.SizeInBytes < 0 {
+			c.add(Error, RuleEnclosure, attachmentPath+".size_in_bytes", "size_in_bytes must not be negative")
+		}
+		if attachment.DurationInSeconds < 0 {
+			c.add(Error, RuleEnclosure, attachmentPath+".duration_in_seconds", "duration_in_seconds must not be negative")
+		}
+	}
+}
+
+func (c *checker) jsonAuthor(path string, author *json.Author) {
+	if author == nil {
+		return
+	}
+	if author.Name == "" && author.URL == "" && author.Avatar == "" {
+		c.add(Error, RuleRequired, path, "author must have at least one of name, url or avatar")
+	}
+	c.url(Error, path+".url", author.URL)
+	c.url(Error, path+".avatar", author.Avatar)
+}
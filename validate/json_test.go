@@ -0,0 +1,62 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseJSON(t *testing.T, data string) *json.Feed {
+	fp := &json.Parser{}
+	feed, err := fp.Parse(strings.NewReader(data))
+	require.Nil(t, err)
+	return feed
+}
+
+func TestJSON_Valid(t *testing.T) {
+	feed := parseJSON(t, `{
+"version": "https://jsonfeed.org/version/1.1",
+"title": "Feed",
+"home_page_url": "https://example.com/",
+"feed_url": "https://example.com/feed.json",
+"items": [{
+	"id": "1",
+	"content_text": "Hello",
+	"date_published": "2020-01-01T00:00:00Z",
+	"attachments": [{"url": "https://example.com/1.mp3", "mime_type": "audio/mpeg"}]
+}]
+}`)
+
+	assert.Empty(t, validate.JSON(feed))
+}
+
+func TestJSON_Invalid(t *testing.T) {
+	feed := parseJSON(t, `{
+"version": "https://jsonfeed.org/version/2",
+"home_page_url": "example.com",
+"authors": [{}],
+"items": [
+	{"id": "1", "date_modified": "Jan 1 2020", "content_html": "<p>Hi</p>"},
+	{"id": "1", "url": "/relative", "attachments": [{"url": "https://example.com/1.mp3", "size_in_bytes": -1}]}
+]
+}`)
+
+	findings := validate.JSON(feed)
+	assert.Equal(t, map[string]validate.Severity{
+		"version":                               validate.Error,
+		"title":                                 validate.Error,
+		"home_page_url":                         validate.Error,
+		"feed_url":                              validate.Info,
+		"authors[0]":                            validate.Error,
+		"items[0].date_modified":                validate.Error,
+		"items[1].id":                           validate.Warning,
+		"items[1]":                              validate.Error,
+		"items[1].url":                          validate.Error,
+		"items[1].attachments[0].mime_type":     validate.Error,
+		"items[1].attachments[0].size_in_bytes": validate.Error,
+	}, paths(findings))
+}
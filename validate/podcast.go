@@ -0,0 +1,243 @@
+package validate
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// appleCategories maps each Apple Podcasts category to
+// the subcategories that may be used with it
+var appleCategories = map[string][]string{
+	"Arts":                    {"Books", "Design", "Fashion & Beauty", "Food", "Performing Arts", "Visual Arts"},
+	"Business":                {"Careers", "Entrepreneurship", "Investing", "Management", "Marketing", "Non-Profit"},
+	"Comedy":                  {"Comedy Interviews", "Improv", "Stand-Up"},
+	"Education":               {"Courses", "How To", "Language Learning", "Self-Improvement"},
+	"Fiction":                 {"Comedy Fiction", "Drama", "Science Fiction"},
+	"Government":              {},
+	"History":                 {},
+	"Health & Fitness":        {"Alternative Health", "Fitness", "Medicine", "Mental Health", "Nutrition", "Sexuality"},
+	"Kids & Family":           {"Education for Kids", "Parenting", "Pets & Animals", "Stories for Kids"},
+	"Leisure":                 {"Animation & Manga", "Automotive", "Aviation", "Crafts", "Games", "Hobbies", "Home & Garden", "Video Games"},
+	"Music":                   {"Music Commentary", "Music History", "Music Interviews"},
+	"News":                    {"Business News", "Daily News", "Entertainment News", "News Commentary", "Politics", "Sports News", "Tech News"},
+	"Religion & Spirituality": {"Buddhism", "Christianity", "Hinduism", "Islam", "Judaism", "Religion", "Spirituality"},
+	"Science":                 {"Astronomy", "Chemistry", "Earth Sciences", "Life Sciences", "Mathematics", "Natural Sciences", "Nature", "Physics", "Social Sciences"},
+	"Society & Culture":       {"Documentary", "Personal Journals", "Philosophy", "Places & Travel", "Relationships"},
+	"Sports":                  {"Baseball", "Basketball", "Cricket", "Fantasy Sports", "Football", "Golf", "Hockey", "Rugby", "Running", "Soccer", "Swimming", "Tennis", "Volleyball", "Wilderness", "Wrestling"},
+	"Technology":              {},
+	"True Crime":              {},
+	"TV & Film":               {"After Shows", "Film History", "Film Interviews", "Film Reviews", "TV Reviews"},
+}
+
+// mediaTypes are the enclosure types accepted by Apple Podcasts
+var mediaTypes = map[string]bool{
+	"audio/mpeg":      true,
+	"audio/x-m4a":     true,
+	"audio/mp4":       true,
+	"audio/aac":       true,
+	"video/mp4":       true,
+	"video/x-m4v":     true,
+	"video/quicktime": true,
+	"application/pdf": true,
+}
+
+// Podcast checks an rss.Feed against the requirements of
+// Apple Podcasts, Spotify and other podcast directories,
+// reporting the problems that would cause a directory to
+// reject the feed or its episodes. It complements RSS, which
+// checks the feed against the RSS and iTunes specifications.
+// Every problem a directory rejects is reported as an Error,
+// so the elements both check are reported by both: a missing
+// description, itunes:image, itunes:category, itunes:explicit
+// or owner email, a missing enclosure length and duplicate
+// guids, which RSS may only warn about.
+func Podcast(feed *rss.Feed) Findings {
+	c := &checker{findings: Findings{}}
+	if feed == nil {
+		c.add(Error, RuleDirectory, "rss", "feed is required")
+		return c.findings
+	}
+
+	channel := "rss/channel"
+	itunes := feed.ITunesExt
+	if itunes == nil {
+		itunes = ext.NewITunesFeedExtension(feed.Extensions["itunes"])
+	}
+
+	if strings.TrimSpace(feed.Description) == "" && itunes.Summary == "" {
+		c.add(Error, RuleDirectory, channel+"/description", "description is required by podcast directories")
+	}
+
+	if itunes.Image == "" {
+		c.add(Error, RuleDirectory, channel+"/itunes:image", "artwork is required by podcast directories")
+	} else {
+		c.artwork(channel+"/itunes:image@href", itunes.Image)
+		c.add(Info, RuleDirectory, channel+"/itunes:image@href",
+			"artwork must be a square JPEG or PNG between 1400x1400 and 3000x3000 pixels")
+	}
+
+	if len(itunes.Categories) == 0 {
+		c.add(Error, RuleDirectory, channel+"/itunes:category", "a category is required by podcast directories")
+	}
+	for i, category := range itunes.Categories {
+		c.category(index(channel+"/itunes:category", i), category)
+	}
+
+	c.explicit(channel+"/itunes:explicit", itunes.Explicit, true)
+
+	if itunes.Owner == nil || itunes.Owner.Email == "" {
+		c.add(Error, RuleDirectory, channel+"/itunes:owner/itunes:email",
+			"an owner email is required to verify ownership of the podcast")
+	}
+
+	if itunes.Type != "" && itunes.Type != "episodic" && itunes.Type != "serial" {
+		c.add(Error, RuleDirectory, channel+"/itunes:type", "%q must be episodic or serial", itunes.Type)
+	}
+
+	guids := map[string]string{}
+	for i, item := range feed.Items {
+		if item == nil {
+			continue
+		}
+		c.episode(index(channel+"/item", i), item, itunes.Type == "serial", guids)
+	}
+	return c.findings
+}
+
+func (c *checker) episode(path string, item *rss.Item, serial bool, guids map[string]string) {
+	itunes := item.ITunesExt
+	if itunes == nil {
+		itunes = ext.NewITunesItemExtension(item.Extensions["itunes"])
+	}
+
+	if item.GUID != nil {
+		guid := strings.TrimSpace(item.GUID.Value)
+		if first, ok := guids[guid]; ok && guid != "" {
+			c.add(Error, RuleDirectory, path+"/guid", "%q duplicates the guid of %s", guid, first)
+		} else {
+			guids[guid] = path + "/guid"
+		}
+	}
+
+	if item.Enclosure != nil {
+		enclosure := path + "/enclosure"
+		if n, err := strconv.ParseInt(strings.TrimSpace(item.Enclosure.Length), 10, 64); err != nil || n <= 0 {
+			c.add(Error, RuleDirectory, enclosure+"@length", "the file size in bytes is required by podcast directories")
+		}
+		if item.Enclosure.Type != "" && !mediaTypes[strings.ToLower(item.Enclosure.Type)] {
+			c.add(Error, RuleDirectory, enclosure+"@type", "%q is not a media type supported by Apple Podcasts", item.Enclosure.Type)
+		}
+		c.https(enclosure+"@url", item.Enclosure.URL)
+	}
+
+	if itunes.Image != "" {
+		c.artwork(path+"/itunes:image@href", itunes.Image)
+	}
+
+	c.explicit(path+"/itunes:explicit", itunes.Explicit, false)
+
+	if itunes.Duration != "" && !validDuration(itunes.Duration) {
+		c.add(Error, RuleDirectory, path+"/itunes:duration", "%q must be a number of seconds or HH:MM:SS", itunes.Duration)
+	}
+
+	if itunes.EpisodeType != "" && itunes.EpisodeType != "full" && itunes.EpisodeType != "trailer" && itunes.EpisodeType != "bonus" {
+		c.add(Error, RuleDirectory, path+"/itunes:episodeType", "%q must be full, trailer or bonus", itunes.EpisodeType)
+	}
+
+	c.positive(path+"/itunes:episode", itunes.Episode)
+	c.positive(path+"/itunes:season", itunes.Season)
+	if serial && itunes.Episode == "" {
+		c.add(Warning, RuleDirectory, path+"/itunes:episode", "episode numbers are required to order the episodes of a serial podcast")
+	}
+}
+
+func (c *checker) artwork(path, href string) {
+	c.https(path, href)
+	if u, err := url.Parse(href); err == nil {
+		switch fileExt(u) {
+		case "jpg", "jpeg", "png", "":
+		default:
+			c.add(Warning, RuleDirectory, path, "%q should be a JPEG or PNG image", href)
+		}
+	}
+}
+
+func (c *checker) https(path, value string) {
+	if value == "" {
+		return
+	}
+	if u, err := url.Parse(strings.TrimSpace(value)); err == nil && u.Scheme == "http" {
+		c.add(Warning, RuleDirectory, path, "%q should be served over HTTPS", value)
+	}
+}
+
+func (c *checker) category(path string, category *ext.ITunesCategory) {
+	if category == nil {
+		return
+	}
+	subcategories, ok := appleCategories[category.Text]
+	if !ok {
+		c.add(Error, RuleDirectory, path+"@text", "%q is not an Apple Podcasts category", category.Text)
+		return
+	}
+	if category.Subcategory == nil {
+		return
+	}
+	for _, sub := range subcategories {
+		if sub == category.Subcategory.Text {
+			return
+		}
+	}
+	c.add(Error, RuleDirectory, path+"/itunes:category@text", "%q is not a subcategory of %q", category.Subcategory.Text, category.Text)
+}
+
+// explicit checks an itunes:explicit value, which Apple Podcasts
+// expects to be true or false. The older yes, no, clean and
+// explicit values are still accepted.
+func (c *checker) explicit(path, value string, required bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "false":
+	case "yes", "no", "clean", "explicit":
+		c.add(Warning, RuleDirectory, path, "%q is deprecated, use true or false", value)
+	case "":
+		if required {
+			c.add(Error, RuleDirectory, path, "itunes:explicit is required by Apple Podcasts")
+		}
+	default:
+		c.add(Error, RuleDirectory, path, "%q must be true or false", value)
+	}
+}
+
+func (c *checker) positive(path, value string) {
+	if value == "" {
+		return
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || n <= 0 {
+		c.add(Error, RuleDirectory, path, "%q must be a positive number", value)
+	}
+}
+
+// validDuration returns true for durations of the form
+// SS, MM:SS or HH:MM:SS
+func validDuration(duration string) bool {
+	parts := strings.Split(strings.TrimSpace(duration), ":")
+	if len(parts) > 3 {
+		return false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (i > 0 && (n > 59 || len(part) != 2)) {
+			return false
+		}
+	}
+	return true
+}
+
+func fileExt(u *url.URL) string {
+	return strings.ToLower(strings.TrimPrefix(path.Ext(u.Path), "."))
+}
@@ -0,0 +1,146 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPodcast_Valid(t *testing.T) {
+	feed := parseRSS(t, `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel>
+<title>Podcast</title>
+<link>https://example.com/</link>
+<description>A podcast</description>
+<language>en</language>
+<itunes:owner><itunes:email>host@example.com</itunes:email></itunes:owner>
+<itunes:image href="https://example.com/cover.jpg"/>
+<itunes:category text="Society &amp; Culture"><itunes:category text="Documentary"/></itunes:category>
+<itunes:explicit>false</itunes:explicit>
+<itunes:type>serial</itunes:type>
+<item>
+<title>Episode 1</title>
+<guid isPermaLink="false">episode-1</guid>
+<enclosure url="https://example.com/1.mp3" length="1024" type="audio/mpeg"/>
+<itunes:duration>1:02:03</itunes:duration>
+<itunes:episode>1</itunes:episode>
+<itunes:episodeType>full</itunes:episodeType>
+</item>
+</channel>
+</rss>`)
+
+	findings := validate.Podcast(feed)
+	require.Len(t, findings, 1)
+	assert.Equal(t, validate.Info, findings[0].Severity)
+	assert.Equal(t, "rss/channel/itunes:image@href", findings[0].Path)
+}
+
+func TestPodcast_Invalid(t *testing.T) {
+	feed := parseRSS(t, `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel>
+<title>Podcast</title>
+<itunes:image href="http://example.com/cover.gif"/>
+<itunes:category text="Technology"><itunes:category text="Podcasting"/></itunes:category>
+<itunes:category text="Gadgets"/>
+<itunes:explicit>maybe</itunes:explicit>
+<itunes:type>weekly</itunes:type>
+<item>
+<title>Episode 1</title>
+<guid>episode</guid>
+<enclosure url="http://example.com/1.ogg" type="audio/ogg"/>
+<itunes:explicit>yes</itunes:explicit>
+<itunes:duration>1:2:3</itunes:duration>
+<itunes:episodeType>special</itunes:episodeType>
+<itunes:season>0</itunes:season>
+</item>
+<item>
+<title>Episode 2</title>
+<guid>episode</guid>
+</item>
+</channel>
+</rss>`)
+
+	findings := validate.Podcast(feed)
+	assert.Equal(t, map[string]validate.Severity{
+		"rss/channel/description":                             validate.Error,
+		"rss/channel/itunes:image@href":                       validate.Info,
+		"rss/channel/itunes:category[0]/itunes:category@text": validate.Error,
+		"rss/channel/itunes:category[1]@text":                 validate.Error,
+		"rss/channel/itunes:explicit":                         validate.Error,
+		"rss/channel/itunes:owner/itunes:email":               validate.Error,
+		"rss/channel/itunes:type":                             validate.Error,
+		"rss/channel/item[0]/enclosure@length":                validate.Error,
+		"rss/channel/item[0]/enclosure@type":                  validate.Error,
+		"rss/channel/item[0]/enclosure@url":                   validate.Warning,
+		"rss/channel/item[0]/itunes:explicit":                 validate.Warning,
+		"rss/channel/item[0]/itunes:duration":                 validate.Error,
+		"rss/channel/item[0]/itunes:episodeType":              validate.Error,
+		"rss/channel/item[0]/itunes:season":                   validate.Error,
+		"rss/channel/item[1]/guid":                            validate.Error,
+	}, paths(findings))
+
+	// The artwork is reported for being served over HTTP and for
+	// not being a JPEG or PNG, as well as the dimensions hint
+	artwork := 0
+	for _, f := range findings {
+		assert.Equal(t, validate.RuleDirectory, f.Rule)
+		if f.Path == "rss/channel/itunes:image@href" {
+			artwork++
+		}
+	}
+	assert.Equal(t, 3, artwork)
+
+	// RSS only warns about the duplicate guid
+	rssPaths := paths(validate.RSS(feed))
+	assert.Equal(t, validate.Warning, rssPaths["rss/channel/item[1]/guid"])
+	assert.ElementsMatch(t, []string{
+		"rss/channel/description",
+		"rss/channel/itunes:owner/itunes:email",
+		"rss/channel/item[0]/enclosure@length",
+		"rss/channel/item[1]/guid",
+	}, sharedPaths(findings, rssPaths))
+}
+
+func TestPodcast_Missing(t *testing.T) {
+	feed := parseRSS(t, `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel>
+<title>Podcast</title>
+<link>https://example.com/</link>
+<description>A podcast</description>
+<language>en</language>
+<itunes:owner><itunes:email>host@example.com</itunes:email></itunes:owner>
+<item>
+<title>Episode 1</title>
+<guid isPermaLink="false">episode-1</guid>
+<enclosure url="https://example.com/1.mp3" length="1024" type="audio/mpeg"/>
+</item>
+</channel>
+</rss>`)
+
+	findings := validate.Podcast(feed)
+	assert.Equal(t, map[string]validate.Severity{
+		"rss/channel/itunes:image":    validate.Error,
+		"rss/channel/itunes:category": validate.Error,
+		"rss/channel/itunes:explicit": validate.Error,
+	}, paths(findings))
+
+	// The missing elements are reported by RSS as well
+	rssPaths := paths(validate.RSS(feed))
+	assert.ElementsMatch(t, []string{
+		"rss/channel/itunes:image",
+		"rss/channel/itunes:category",
+		"rss/channel/itunes:explicit",
+	}, sharedPaths(findings, rssPaths))
+}
+
+func sharedPaths(findings validate.Findings, other map[string]validate.Severity) []string {
+	shared := []string{}
+	for path := range paths(findings) {
+		if _, ok := other[path]; ok {
+			shared = append(shared, path)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,141 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed/rss"
+)
+
+var rssDays = map[string]bool{
+	"Monday": true, "Tuesday": true, "Wednesday": true, "Thursday": true,
+	"Friday": true, "Saturday": true, "Sunday": true,
+}
+
+// RSS checks an rss.Feed against the RSS 2.0 specification,
+// or RSS 1.0 for feeds parsed from an RDF document. Feeds
+// that use the itunes extension are also checked against the
+// iTunes podcast requirements.
+func RSS(feed *rss.Feed) Findings {
+	c := &checker{findings: Findings{}}
+	if feed == nil {
+		c.add(Error, RuleRequired, "rss", "feed is required")
+		return c.findings
+	}
+
+	root := "rss"
+	if name := strings.ToLower(feed.RootName); name == "rdf" || strings.HasSuffix(name, ":rdf") {
+		root = "rdf:RDF"
+	}
+	channel := root + "/channel"
+
+	c.required(channel+"/title", feed.Title)
+	if c.required(channel+"/link", feed.Link) {
+		c.url(Error, channel+"/link", feed.Link)
+	}
+	c.required(channel+"/description", feed.Description)
+	c.email(channel+"/managingEditor", feed.ManagingEditor)
+	c.email(channel+"/webMaster", feed.WebMaster)
+	c.rfc822(channel+"/pubDate", feed.PubDate)
+	c.rfc822(channel+"/lastBuildDate", feed.LastBuildDate)
+	c.url(Warning, channel+"/docs", feed.Docs)
+
+	if feed.TTL != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(feed.TTL)); err != nil || n < 0 {
+			c.add(Error, RuleValue, channel+"/ttl", "%q is not a number of minutes", feed.TTL)
+		}
+	}
+
+	for i, hour := range feed.SkipHours {
+		if n, err := strconv.Atoi(strings.TrimSpace(hour)); err != nil || n < 0 || n > 23 {
+			c.add(Error, RuleValue, index(channel+"/skipHours/hour", i), "%q is not an hour between 0 and 23", hour)
+		}
+	}
+
+	for i, day := range feed.SkipDays {
+		if !rssDays[strings.TrimSpace(day)] {
+			c.add(Error, RuleValue, index(channel+"/skipDays/day", i), "%q is not a day of the week", day)
+		}
+	}
+
+	if feed.Image != nil {
+		path := channel + "/image"
+		if root != "rss" {
+			path = root + "/image"
+		}
+		if c.required(path+"/url", feed.Image.URL) {
+			c.url(Error, path+"/url", feed.Image.URL)
+		}
+		c.required(path+"/title", feed.Image.Title)
+		if c.required(path+"/link", feed.Image.Link) {
+			c.url(Error, path+"/link", feed.Image.Link)
+		}
+	}
+
+	items := channel + "/item"
+	if root != "rss" {
+		items = root + "/item"
+	}
+	guids := map[string]string{}
+	for i, item := range feed.Items {
+		if item != nil {
+			c.rssItem(index(items, i), item, guids)
+		}
+	}
+
+	if feed.ITunesExt != nil || feed.Extensions["itunes"] != nil {
+		c.itunes(channel, feed)
+	}
+	return c.findings
+}
+
+func (c *checker) rssItem(path string, item *rss.Item, guids map[string]string) {
+	if strings.TrimSpace(item.Title) == "" && strings.TrimSpace(item.Description) == "" {
+		c.add(Error, RuleRequired, path, "item must have a title or a description")
+	}
+	c.url(Error, path+"/link", item.Link)
+	c.url(Error, path+"/comments", item.Comments)
+	c.email(path+"/author", item.Author)
+	c.rfc822(path+"/pubDate", item.PubDate)
+
+	if item.GUID != nil {
+		guid := strings.TrimSpace(item.GUID.Value)
+		if guid == "" {
+			c.add(Error, RuleRequired, path+"/guid", "guid must not be empty")
+		} else if item.GUID.IsPermalink != "false" {
+			c.url(Error, path+"/guid", guid)
+		}
+		if item.GUID.IsPermalink != "" && item.GUID.IsPermalink != "true" && item.GUID.IsPermalink != "false" {
+			c.add(Error, RuleValue, path+"/guid@isPermaLink", "%q must be true or false", item.GUID.IsPermalink)
+		}
+		c.unique(guids, path+"/guid", guid)
+	}
+
+	if item.Enclosure != nil {
+		enclosure := path + "/enclosure"
+		if item.Enclosure.URL == "" {
+			c.add(Error, RuleEnclosure, enclosure+"@url", "url is required")
+		}
+		c.url(Error, enclosure+"@url", item.Enclosure.URL)
+		c.length(enclosure+"@length", item.Enclosure.Length)
+		c.mimeType(enclosure+"@type", item.Enclosure.Type)
+	}
+
+	if item.Source != nil {
+		if c.required(path+"/source@url", item.Source.URL) {
+			c.url(Error, path+"/source@url", item.Source.URL)
+		}
+	}
+}
+
+// email reports an RSS person element which does not
+// start with an email address
+func (c *checker) email(path, value string) {
+	if value == "" {
+		return
+	}
+	fields := strings.Fields(value)
+	if len(fields) == 0 || !strings.Contains(fields[0], "@") {
+		c.add(Warning, RuleValue, path, "%q should be an email address, optionally followed by a name", value)
+	}
+}
@@ -0,0 +1,151 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/mmcdole/gofeed/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseRSS(t *testing.T, data string) *rss.Feed {
+	fp := &rss.Parser{}
+	feed, err := fp.Parse(strings.NewReader(data))
+	require.Nil(t, err)
+	return feed
+}
+
+func paths(findings validate.Findings) map[string]validate.Severity {
+	result := map[string]validate.Severity{}
+	for _, f := range findings {
+		result[f.Path] = f.Severity
+	}
+	return result
+}
+
+func TestRSS_Valid(t *testing.T) {
+	feed := parseRSS(t, `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel>
+<title>Podcast</title>
+<link>http://example.com/</link>
+<description>A podcast</description>
+<language>en</language>
+<pubDate>Mon, 06 Jan 2020 10:00:00 GMT</pubDate>
+<itunes:author>Host</itunes:author>
+<itunes:owner><itunes:email>host@example.com</itunes:email></itunes:owner>
+<itunes:image href="http://example.com/cover.jpg"/>
+<itunes:category text="Technology"/>
+<itunes:explicit>false</itunes:explicit>
+<item>
+<title>Episode 1</title>
+<guid isPermaLink="false">episode-1</guid>
+<pubDate>6 Jan 2020 10:00 +0000</pubDate>
+<enclosure url="http://example.com/1.mp3" length="1024" type="audio/mpeg"/>
+</item>
+</channel>
+</rss>`)
+
+	findings := validate.RSS(feed)
+	assert.Empty(t, findings)
+	assert.False(t, findings.HasErrors())
+}
+
+func TestRSS_Invalid(t *testing.T) {
+	feed := parseRSS(t, `<rss version="2.0">
+<channel>
+<title>Feed</title>
+<link>/relative</link>
+<ttl>soon</ttl>
+<pubDate>2020-01-06T10:00:00Z</pubDate>
+<skipDays><day>Someday</day></skipDays>
+<item>
+<guid>http://example.com/1</guid>
+</item>
+<item>
+<title>Item 2</title>
+<guid>http://example.com/1</guid>
+<pubDate>yesterday</pubDate>
+<enclosure url="http://example.com/1.mp3" length="big" type="audio"/>
+</item>
+</channel>
+</rss>`)
+
+	findings := validate.RSS(feed)
+	assert.True(t, findings.HasErrors())
+	assert.Equal(t, map[string]validate.Severity{
+		"rss/channel/link":                     validate.Error,
+		"rss/channel/description":              validate.Error,
+		"rss/channel/ttl":                      validate.Error,
+		"rss/channel/pubDate":                  validate.Warning,
+		"rss/channel/skipDays/day[0]":          validate.Error,
+		"rss/channel/item[0]":                  validate.Error,
+		"rss/channel/item[1]/guid":             validate.Warning,
+		"rss/channel/item[1]/pubDate":          validate.Error,
+		"rss/channel/item[1]/enclosure@length": validate.Error,
+		"rss/channel/item[1]/enclosure@type":   validate.Error,
+	}, paths(findings))
+	assert.Len(t, findings.Filter(validate.Error), 8)
+}
+
+func TestRSS_ITunes(t *testing.T) {
+	feed := parseRSS(t, `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel>
+<title>Podcast</title>
+<link>http://example.com/</link>
+<description>A podcast</description>
+<itunes:author>Host</itunes:author>
+<item>
+<title>Episode 1</title>
+</item>
+</channel>
+</rss>`)
+
+	findings := validate.RSS(feed)
+	assert.Equal(t, map[string]validate.Severity{
+		"rss/channel/language":                  validate.Error,
+		"rss/channel/itunes:image":              validate.Error,
+		"rss/channel/itunes:category":           validate.Error,
+		"rss/channel/itunes:explicit":           validate.Error,
+		"rss/channel/itunes:owner/itunes:email": validate.Warning,
+		"rss/channel/item[0]/enclosure":         validate.Error,
+		"rss/channel/item[0]/guid":              validate.Warning,
+	}, paths(findings))
+	for _, f := range findings {
+		assert.Equal(t, validate.RuleITunes, f.Rule)
+	}
+}
+
+func TestRSS_RDF(t *testing.T) {
+	feed := parseRSS(t, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
+<channel rdf:about="http://example.com/">
+<title>Feed</title>
+<link>http://example.com/</link>
+<description>Description</description>
+</channel>
+<item rdf:about="http://example.com/1">
+<link>http://example.com/1</link>
+</item>
+</rdf:RDF>`)
+
+	findings := validate.RSS(feed)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "rdf:RDF/item[0]", findings[0].Path)
+	assert.Equal(t, "error: rdf:RDF/item[0]: item must have a title or a description", findings[0].String())
+}
+
+func TestRSS_BlankEmail(t *testing.T) {
+	feed := &rss.Feed{
+		Version:        "2.0",
+		Title:          "Feed",
+		Link:           "http://example.com/",
+		Description:    "Description",
+		ManagingEditor: " \t\n",
+	}
+
+	findings := validate.RSS(feed)
+	assert.Equal(t, map[string]validate.Severity{
+		"rss/channel/managingEditor": validate.Warning,
+	}, paths(findings))
+}
@@ -0,0 +1,207 @@
+// Package validate checks parsed rss, atom and json feeds
+// against their specifications and reports the problems
+// found as a list of findings.
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed/internal/shared"
+)
+
+// Severity is how serious a Finding is
+type Severity int
+
+const (
+	// Info findings are suggestions which improve
+	// interoperability with feed readers
+	Info Severity = iota
+	// Warning findings are allowed by the spec but
+	// are likely to cause problems
+	Warning
+	// Error findings are violations of the spec
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return "unknown"
+}
+
+// MarshalText encodes the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Names of the rules which produce findings
+const (
+	RuleRequired  = "required"
+	RuleDate      = "date"
+	RuleURL       = "url"
+	RuleUniqueID  = "unique-id"
+	RuleEnclosure = "enclosure"
+	RuleValue     = "value"
+	RuleITunes    = "itunes"
+	RuleDirectory = "directory"
+)
+
+// Finding is a single problem found in a feed. Path is the
+// location of the offending element, such as
+// "rss/channel/item[2]/enclosure@length", where item
+// indexes start at 0.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Path     string   `json:"path"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Path, f.Message)
+}
+
+// Findings is the list of problems found in a feed
+type Findings []Finding
+
+// HasErrors returns true if any of the findings is an Error
+func (f Findings) HasErrors() bool {
+	return len(f.Filter(Error)) > 0
+}
+
+// Filter returns the findings with at least the given severity
+func (f Findings) Filter(min Severity) Findings {
+	result := Findings{}
+	for _, finding := range f {
+		if finding.Severity >= min {
+			result = append(result, finding)
+		}
+	}
+	return result
+}
+
+type checker struct {
+	findings Findings
+}
+
+func (c *checker) add(severity Severity, rule, path, format string, args ...interface{}) {
+	c.findings = append(c.findings, Finding{
+		Severity: severity,
+		Rule:     rule,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) required(path, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		c.add(Error, RuleRequired, path, "element is required")
+		return false
+	}
+	return true
+}
+
+// url reports an empty or relative URL. Empty values are
+// ignored as optional elements are checked by required.
+func (c *checker) url(severity Severity, path, value string) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		c.add(Error, RuleURL, path, "%q is not a valid URL", value)
+	} else if !u.IsAbs() || (u.Host == "" && u.Scheme != "mailto" && u.Scheme != "urn" && u.Scheme != "tag") {
+		c.add(severity, RuleURL, path, "%q is not an absolute URL", value)
+	}
+}
+
+func (c *checker) rfc3339(path, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err != nil {
+		c.add(Error, RuleDate, path, "%q is not an RFC 3339 date", value)
+	}
+}
+
+// rfc822Layouts are the forms of an RFC 822 date, after the
+// optional day of the week has been removed
+var rfc822Layouts = []string{
+	"2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 06 15:04:05 MST",
+	"2 Jan 06 15:04:05 -0700",
+	"2 Jan 06 15:04 MST",
+	"2 Jan 06 15:04 -0700",
+}
+
+func (c *checker) rfc822(path, value string) {
+	if value == "" {
+		return
+	}
+	date := strings.TrimSpace(value)
+	if i := strings.Index(date, ","); i >= 0 {
+		date = strings.TrimSpace(date[i+1:])
+	}
+	for _, layout := range rfc822Layouts {
+		if _, err := time.Parse(layout, date); err == nil {
+			return
+		}
+	}
+	if _, err := shared.ParseDate(value); err == nil {
+		c.add(Warning, RuleDate, path, "%q is not an RFC 822 date", value)
+	} else {
+		c.add(Error, RuleDate, path, "%q is not a valid date", value)
+	}
+}
+
+func (c *checker) length(path, value string) {
+	if value == "" {
+		c.add(Error, RuleEnclosure, path, "length is required")
+		return
+	}
+	if n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err != nil || n < 0 {
+		c.add(Error, RuleEnclosure, path, "%q is not a length in bytes", value)
+	} else if n == 0 {
+		c.add(Warning, RuleEnclosure, path, "length should be the size of the file in bytes")
+	}
+}
+
+func (c *checker) mimeType(path, value string) {
+	if value == "" {
+		c.add(Error, RuleEnclosure, path, "type is required")
+		return
+	}
+	if parts := strings.Split(value, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		c.add(Error, RuleEnclosure, path, "%q is not a MIME type", value)
+	}
+}
+
+// unique reports an id which was already seen, keeping
+// track of the path where each id first appeared
+func (c *checker) unique(seen map[string]string, path, id string) {
+	if id == "" {
+		return
+	}
+	if first, ok := seen[id]; ok {
+		c.add(Warning, RuleUniqueID, path, "%q duplicates the id of %s", id, first)
+		return
+	}
+	seen[id] = path
+}
+
+func index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
@@ -0,0 +1,485 @@
+// Package websub implements a WebSub (formerly PubSubHubbub)
+// subscriber which subscribes to the hubs advertised by a feed
+// and parses the content the hubs push to it.
+// https://www.w3.org/TR/websub/
+package websub
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// ErrNoHub is returned by SubscribeFeed when the feed
+// does not advertise a WebSub hub
+var ErrNoHub = errors.New("Feed does not advertise a WebSub hub")
+
+// ErrNoTopic is returned by SubscribeFeed when the feed
+// has no self link to subscribe to
+var ErrNoTopic = errors.New("Feed does not have a self link")
+
+// DefaultMaxContentLength is the size in bytes of the largest
+// content accepted when Subscriber does not set MaxContentLength
+const DefaultMaxContentLength = 10 << 20
+
+// State is the state of a Subscription
+type State int
+
+const (
+	// Pending subscriptions are waiting for the hub to
+	// verify the intent to subscribe
+	Pending State = iota
+	// Active subscriptions have been verified by the hub
+	// and receive content
+	Active
+	// Unsubscribing subscriptions are waiting for the hub
+	// to verify the intent to unsubscribe
+	Unsubscribing
+	// Unsubscribed subscriptions have been removed from the hub
+	Unsubscribed
+	// Denied subscriptions were refused by the hub
+	Denied
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Active:
+		return "active"
+	case Unsubscribing:
+		return "unsubscribing"
+	case Unsubscribed:
+		return "unsubscribed"
+	case Denied:
+		return "denied"
+	}
+	return "unknown"
+}
+
+// Subscription is a subscription to a topic at a hub.
+// Expires is the end of the lease granted by the hub, after
+// which the subscription must be renewed with Subscribe.
+// Reason is the reason given by the hub for a denial.
+type Subscription struct {
+	ID       string
+	Hub      string
+	Topic    string
+	Callback string
+	Secret   string
+	State    State
+	Expires  time.Time
+	Reason   string
+}
+
+// Subscriber subscribes to topics at WebSub hubs.  It is an
+// http.Handler which must be reachable by the hubs at Callback,
+// where it answers the verification of intent requests of the
+// hubs and receives their content distribution requests.
+//
+// Content is parsed with Parser and passed to OnFeed.  Content
+// which cannot be parsed, or whose signature is invalid, is
+// passed to OnError instead.  As a Parser is not safe for
+// concurrent use, content received concurrently is parsed one
+// at a time.  Content larger than MaxContentLength is refused.
+type Subscriber struct {
+	Callback         string
+	LeaseSeconds     int
+	MaxContentLength int64
+	Parser           *gofeed.Parser
+	Client           *http.Client
+	OnFeed           func(sub Subscription, feed *gofeed.Feed)
+	OnError          func(sub Subscription, err error)
+
+	mu            sync.Mutex
+	parseMu       sync.Mutex
+	subscriptions map[string]*Subscription
+}
+
+// NewSubscriber creates a Subscriber which is reachable
+// by hubs at the callback URL
+func NewSubscriber(callback string) *Subscriber {
+	return &Subscriber{
+		Callback:         callback,
+		MaxContentLength: DefaultMaxContentLength,
+		Parser:           gofeed.NewParser(),
+		subscriptions:    map[string]*Subscription{},
+	}
+}
+
+// SubscribeFeed subscribes to the self link of the feed at the
+// first hub the feed advertises
+func (s *Subscriber) SubscribeFeed(feed *gofeed.Feed) (Subscription, error) {
+	return s.SubscribeFeedWithContext(feed, context.Background())
+}
+
+// SubscribeFeedWithContext subscribes to the self link of the feed
+// at the first hub the feed advertises with a custom context
+func (s *Subscriber) SubscribeFeedWithContext(feed *gofeed.Feed, ctx context.Context) (Subscription, error) {
+	if len(feed.Hubs) == 0 {
+		return Subscription{}, ErrNoHub
+	}
+	if feed.FeedLink == "" {
+		return Subscription{}, ErrNoTopic
+	}
+	return s.SubscribeWithContext(feed.Hubs[0], feed.FeedLink, ctx)
+}
+
+// Subscribe asks the hub to send the content of the topic to the
+// Subscriber.  The returned subscription is Pending until the hub
+// verifies the intent to subscribe, which some hubs do before they
+// accept the request.  Subscribing to a topic again renews the
+// lease of the existing subscription.
+func (s *Subscriber) Subscribe(hub, topic string) (Subscription, error) {
+	return s.SubscribeWithContext(hub, topic, context.Background())
+}
+
+// SubscribeWithContext asks the hub to send the content of the
+// topic to the Subscriber with a custom context
+func (s *Subscriber) SubscribeWithContext(hub, topic string, ctx context.Context) (Subscription, error) {
+	sub, err := s.pending(hub, topic)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	params := url.Values{}
+	params.Set("hub.mode", "subscribe")
+	params.Set("hub.topic", sub.Topic)
+	params.Set("hub.callback", sub.Callback)
+	params.Set("hub.secret", sub.Secret)
+	if s.LeaseSeconds > 0 {
+		params.Set("hub.lease_seconds", strconv.Itoa(s.LeaseSeconds))
+	}
+
+	if err := s.request(ctx, hub, params); err != nil {
+		s.setState(sub.ID, Denied, err.Error())
+		return s.subscription(sub.ID), err
+	}
+	return s.subscription(sub.ID), nil
+}
+
+// Unsubscribe asks the hub to stop sending the content of the
+// subscription.  The subscription is Unsubscribing until the hub
+// verifies the intent to unsubscribe.
+func (s *Subscriber) Unsubscribe(sub Subscription) error {
+	return s.UnsubscribeWithContext(sub, context.Background())
+}
+
+// UnsubscribeWithContext asks the hub to stop sending the content
+// of the subscription with a custom context
+func (s *Subscriber) UnsubscribeWithContext(sub Subscription, ctx context.Context) error {
+	s.mu.Lock()
+	current, ok := s.subscriptions[sub.ID]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("Unknown subscription %s", sub.ID)
+	}
+	previous := current.State
+	current.State = Unsubscribing
+	hub, topic, callback := current.Hub, current.Topic, current.Callback
+	s.mu.Unlock()
+
+	params := url.Values{}
+	params.Set("hub.mode", "unsubscribe")
+	params.Set("hub.topic", topic)
+	params.Set("hub.callback", callback)
+	if err := s.request(ctx, hub, params); err != nil {
+		s.mu.Lock()
+		if current.State == Unsubscribing {
+			current.State = previous
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Subscriptions returns the subscriptions of the Subscriber
+func (s *Subscriber) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]Subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		result = append(result, *sub)
+	}
+	return result
+}
+
+// ServeHTTP answers verification of intent requests with GET and
+// receives content distribution requests with POST
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("subscription")
+	switch r.Method {
+	case http.MethodGet:
+		s.verify(w, r, id)
+	case http.MethodPost:
+		s.receive(w, r, id)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify confirms the intent of a pending subscribe or
+// unsubscribe request by echoing the challenge of the hub
+func (s *Subscriber) verify(w http.ResponseWriter, r *http.Request, id string) {
+	query := r.URL.Query()
+	mode := query.Get("hub.mode")
+	topic := query.Get("hub.topic")
+
+	s.mu.Lock()
+	sub, ok := s.subscriptions[id]
+	if !ok || sub.Topic != topic {
+		s.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case mode == "denied":
+		sub.State = Denied
+		sub.Reason = query.Get("hub.reason")
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		return
+	case mode == "subscribe" && (sub.State == Pending || sub.State == Active):
+		sub.State = Active
+		if lease, err := strconv.Atoi(query.Get("hub.lease_seconds")); err == nil && lease > 0 {
+			sub.Expires = time.Now().Add(time.Duration(lease) * time.Second)
+		}
+	case mode == "unsubscribe" && sub.State == Unsubscribing:
+		sub.State = Unsubscribed
+	default:
+		s.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, query.Get("hub.challenge"))
+}
+
+// receive parses the content pushed by the hub.  As the
+// specification requires, content with an invalid signature is
+// acknowledged but ignored.
+func (s *Subscriber) receive(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	sub, ok := s.subscriptions[id]
+	var current Subscription
+	if ok {
+		current = *sub
+	}
+	s.mu.Unlock()
+
+	if !ok || current.State != Active {
+		http.NotFound(w, r)
+		return
+	}
+
+	limit := s.MaxContentLength
+	if limit <= 0 {
+		limit = DefaultMaxContentLength
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+
+	if !VerifySignature(current.Secret, r.Header.Get("X-Hub-Signature"), body) {
+		s.fail(current, fmt.Errorf("Invalid signature for content of %s", current.Topic))
+		return
+	}
+
+	s.parseMu.Lock()
+	feed, err := s.parser().Parse(bytes.NewReader(body))
+	s.parseMu.Unlock()
+	if err != nil {
+		s.fail(current, err)
+		return
+	}
+	if s.OnFeed != nil {
+		s.OnFeed(current, feed)
+	}
+}
+
+func (s *Subscriber) fail(sub Subscription, err error) {
+	if s.OnError != nil {
+		s.OnError(sub, err)
+	}
+}
+
+// pending records a new pending subscription, or marks the
+// existing subscription to the topic at the hub as pending.
+func (s *Subscriber) pending(hub, topic string) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscriptions == nil {
+		s.subscriptions = map[string]*Subscription{}
+	}
+
+	for _, sub := range s.subscriptions {
+		if sub.Hub == hub && sub.Topic == topic {
+			if sub.State != Active {
+				sub.State = Pending
+			}
+			sub.Reason = ""
+			return *sub, nil
+		}
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return Subscription{}, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return Subscription{}, err
+	}
+	callback, err := callbackURL(s.Callback, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub := &Subscription{ID: id, Hub: hub, Topic: topic, Callback: callback, Secret: secret, State: Pending}
+	s.subscriptions[id] = sub
+	return *sub, nil
+}
+
+func (s *Subscriber) subscription(id string) Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.subscriptions[id]; ok {
+		return *sub
+	}
+	return Subscription{}
+}
+
+func (s *Subscriber) setState(id string, state State, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.subscriptions[id]; ok {
+		sub.State = state
+		sub.Reason = reason
+	}
+}
+
+// request sends a subscribe or unsubscribe request to the hub,
+// which must accept it with a 2xx status code.
+func (s *Subscriber) request(ctx context.Context, hub string, params url.Values) error {
+	req, err := http.NewRequest(http.MethodPost, hub, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", s.parser().UserAgent)
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return nil
+}
+
+func (s *Subscriber) parser() *gofeed.Parser {
+	if s.Parser != nil {
+		return s.Parser
+	}
+	return gofeed.NewParser()
+}
+
+func (s *Subscriber) client() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+// VerifySignature reports whether the X-Hub-Signature header,
+// such as "sha256=4f2...", is the HMAC of the body keyed with the
+// secret.  The sha1, sha256, sha384 and sha512 methods are
+// supported.  Content is always valid when there is no secret.
+func VerifySignature(secret, header string, body []byte) bool {
+	if secret == "" {
+		return true
+	}
+
+	parts := strings.SplitN(header, "=", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	var h func() hash.Hash
+	switch strings.ToLower(parts[0]) {
+	case "sha1":
+		h = sha1.New
+	case "sha256":
+		h = sha256.New
+	case "sha384":
+		h = sha512.New384
+	case "sha512":
+		h = sha512.New
+	default:
+		return false
+	}
+
+	signature, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(signature, mac.Sum(nil))
+}
+
+// callbackURL adds the subscription id to the query of the
+// callback URL of the subscriber
+func callbackURL(callback, id string) (string, error) {
+	u, err := url.Parse(callback)
+	if err != nil {
+		return "", err
+	}
+	if !u.IsAbs() {
+		return "", fmt.Errorf("Callback %q must be an absolute URL", callback)
+	}
+	query := u.Query()
+	query.Set("subscription", id)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,294 @@
+package websub_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/websub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pushedFeed = `<feed xmlns="http://www.w3.org/2005/Atom">
+<title>Pushed</title>
+<link rel="self" href="http://example.com/feed"/>
+<entry><id>urn:1</id><title>New entry</title></entry>
+</feed>`
+
+// stubHub is a hub which verifies the intent of subscribers
+// before it accepts their requests, and can push content to
+// the subscribers it has verified.
+type stubHub struct {
+	t      *testing.T
+	deny   bool
+	mu     sync.Mutex
+	topics map[string]url.Values
+}
+
+func (h *stubHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.Nil(h.t, r.ParseForm())
+	mode := r.PostForm.Get("hub.mode")
+	callback := r.PostForm.Get("hub.callback")
+	topic := r.PostForm.Get("hub.topic")
+	if mode == "" || callback == "" || topic == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	verify, err := url.Parse(callback)
+	require.Nil(h.t, err)
+	query := verify.Query()
+	query.Set("hub.topic", topic)
+	if h.deny {
+		query.Set("hub.mode", "denied")
+		query.Set("hub.reason", "Not allowed")
+	} else {
+		query.Set("hub.mode", mode)
+		query.Set("hub.challenge", "challenge-"+mode)
+		query.Set("hub.lease_seconds", "3600")
+	}
+	verify.RawQuery = query.Encode()
+
+	resp, err := http.Get(verify.String())
+	require.Nil(h.t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if !h.deny && (resp.StatusCode != http.StatusOK || string(body) != "challenge-"+mode) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.mu.Lock()
+	if mode == "subscribe" && !h.deny {
+		h.topics[callback] = r.PostForm
+	} else {
+		delete(h.topics, callback)
+	}
+	h.mu.Unlock()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// publish pushes the content to every subscriber, signing it
+// with the secret of the subscription unless secret is given.
+func (h *stubHub) publish(content string, secret string) {
+	h.mu.Lock()
+	topics := map[string]url.Values{}
+	for callback, form := range h.topics {
+		topics[callback] = form
+	}
+	h.mu.Unlock()
+
+	for callback, form := range topics {
+		key := secret
+		if key == "" {
+			key = form.Get("hub.secret")
+		}
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(content))
+
+		req, err := http.NewRequest(http.MethodPost, callback, strings.NewReader(content))
+		require.Nil(h.t, err)
+		req.Header.Set("Content-Type", "application/atom+xml")
+		req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(h.t, err)
+		resp.Body.Close()
+		assert.Equal(h.t, http.StatusAccepted, resp.StatusCode)
+	}
+}
+
+func newStub(t *testing.T) (*stubHub, *httptest.Server, *websub.Subscriber, *httptest.Server) {
+	hub := &stubHub{t: t, topics: map[string]url.Values{}}
+	hubServer := httptest.NewServer(hub)
+
+	subscriber := websub.NewSubscriber("")
+	subscriberServer := httptest.NewServer(subscriber)
+	subscriber.Callback = subscriberServer.URL + "/callback"
+	return hub, hubServer, subscriber, subscriberServer
+}
+
+func TestSubscriber_Subscribe(t *testing.T) {
+	hub, hubServer, subscriber, subscriberServer := newStub(t)
+	defer hubServer.Close()
+	defer subscriberServer.Close()
+
+	var feeds []*gofeed.Feed
+	var errs []error
+	subscriber.OnFeed = func(sub websub.Subscription, feed *gofeed.Feed) { feeds = append(feeds, feed) }
+	subscriber.OnError = func(sub websub.Subscription, err error) { errs = append(errs, err) }
+
+	sub, err := subscriber.Subscribe(hubServer.URL, "http://example.com/feed")
+	require.Nil(t, err)
+	assert.Equal(t, websub.Active, sub.State)
+	assert.False(t, sub.Expires.IsZero())
+	assert.True(t, strings.HasPrefix(sub.Callback, subscriberServer.URL+"/callback?subscription="))
+	assert.NotEmpty(t, sub.Secret)
+
+	hub.publish(pushedFeed, "")
+	require.Len(t, feeds, 1)
+	assert.Equal(t, "Pushed", feeds[0].Title)
+	require.Len(t, feeds[0].Items, 1)
+	assert.Equal(t, "New entry", feeds[0].Items[0].Title)
+	assert.Empty(t, errs)
+
+	hub.publish(pushedFeed, "wrong secret")
+	assert.Len(t, feeds, 1)
+	require.Len(t, errs, 1)
+
+	require.Nil(t, subscriber.Unsubscribe(sub))
+	subs := subscriber.Subscriptions()
+	require.Len(t, subs, 1)
+	assert.Equal(t, websub.Unsubscribed, subs[0].State)
+	assert.Empty(t, hub.topics)
+}
+
+func TestSubscriber_ConcurrentPushes(t *testing.T) {
+	hub, hubServer, subscriber, subscriberServer := newStub(t)
+	defer hubServer.Close()
+	defer subscriberServer.Close()
+
+	var mu sync.Mutex
+	feeds := 0
+	subscriber.OnFeed = func(sub websub.Subscription, feed *gofeed.Feed) {
+		mu.Lock()
+		feeds++
+		mu.Unlock()
+	}
+	subscriber.OnError = func(sub websub.Subscription, err error) { t.Error(err) }
+
+	_, err := subscriber.Subscribe(hubServer.URL, "http://example.com/feed")
+	require.Nil(t, err)
+
+	content := strings.Replace(pushedFeed, "<entry>", strings.Repeat("<entry><id>urn:0</id></entry>", 100)+"<entry>", 1)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hub.publish(content, "")
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, feeds)
+}
+
+func TestSubscriber_ContentTooLarge(t *testing.T) {
+	_, hubServer, subscriber, subscriberServer := newStub(t)
+	defer hubServer.Close()
+	defer subscriberServer.Close()
+
+	fed := false
+	subscriber.OnFeed = func(sub websub.Subscription, feed *gofeed.Feed) { fed = true }
+	subscriber.MaxContentLength = 16
+
+	sub, err := subscriber.Subscribe(hubServer.URL, "http://example.com/feed")
+	require.Nil(t, err)
+
+	resp, err := http.Post(sub.Callback, "application/atom+xml", strings.NewReader(pushedFeed))
+	require.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.False(t, fed)
+}
+
+func TestSubscriber_SubscribeFeed(t *testing.T) {
+	_, hubServer, subscriber, subscriberServer := newStub(t)
+	defer hubServer.Close()
+	defer subscriberServer.Close()
+
+	feed, err := gofeed.NewParser().ParseString(fmt.Sprintf(`<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+<channel>
+<title>Title</title>
+<atom:link rel="hub" href="%s"/>
+<atom:link rel="self" href="http://example.com/rss"/>
+</channel>
+</rss>`, hubServer.URL))
+	require.Nil(t, err)
+	assert.Equal(t, []string{hubServer.URL}, feed.Hubs)
+
+	sub, err := subscriber.SubscribeFeed(feed)
+	require.Nil(t, err)
+	assert.Equal(t, hubServer.URL, sub.Hub)
+	assert.Equal(t, "http://example.com/rss", sub.Topic)
+	assert.Equal(t, websub.Active, sub.State)
+
+	_, err = subscriber.SubscribeFeed(&gofeed.Feed{FeedLink: "http://example.com/rss"})
+	assert.Equal(t, websub.ErrNoHub, err)
+	_, err = subscriber.SubscribeFeed(&gofeed.Feed{Hubs: []string{hubServer.URL}})
+	assert.Equal(t, websub.ErrNoTopic, err)
+}
+
+func TestSubscriber_Denied(t *testing.T) {
+	hub, hubServer, subscriber, subscriberServer := newStub(t)
+	defer hubServer.Close()
+	defer subscriberServer.Close()
+	hub.deny = true
+
+	sub, err := subscriber.Subscribe(hubServer.URL, "http://example.com/feed")
+	require.Nil(t, err)
+	assert.Equal(t, websub.Denied, sub.State)
+	assert.Equal(t, "Not allowed", sub.Reason)
+}
+
+func TestSubscriber_HubError(t *testing.T) {
+	hubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer hubServer.Close()
+
+	subscriber := websub.NewSubscriber("http://example.com/callback")
+	sub, err := subscriber.Subscribe(hubServer.URL, "http://example.com/feed")
+	require.NotNil(t, err)
+	httpErr, ok := err.(gofeed.HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+	assert.Equal(t, websub.Denied, sub.State)
+}
+
+func TestSubscriber_Verify(t *testing.T) {
+	subscriber := websub.NewSubscriber("http://example.com/callback")
+
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{"subscription=unknown&hub.mode=subscribe&hub.topic=http://example.com/feed&hub.challenge=c", http.StatusNotFound},
+		{"hub.mode=subscribe&hub.topic=http://example.com/feed&hub.challenge=c", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		subscriber.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/callback?"+test.query, nil))
+		assert.Equal(t, test.status, w.Code, test.query)
+	}
+
+	w := httptest.NewRecorder()
+	subscriber.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/callback", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte("content")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, websub.VerifySignature("secret", "sha256="+signature, body))
+	assert.True(t, websub.VerifySignature("", "", body))
+	assert.False(t, websub.VerifySignature("secret", "sha256="+signature, []byte("other")))
+	assert.False(t, websub.VerifySignature("other", "sha256="+signature, body))
+	assert.False(t, websub.VerifySignature("secret", "sha1="+signature, body))
+	assert.False(t, websub.VerifySignature("secret", "md5="+signature, body))
+	assert.False(t, websub.VerifySignature("secret", "", body))
+	assert.False(t, websub.VerifySignature("secret", "sha256=zz", body))
+}
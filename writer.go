@@ -0,0 +1,48 @@
+package gofeed
+
+import (
+	"io"
+
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// WriteRSS serializes the feed as RSS 2.0 to w using
+// the DefaultRSSReverseTranslator.
+func (f Feed) WriteRSS(w io.Writer) error {
+	translated, err := (&DefaultRSSReverseTranslator{}).Translate(&f)
+	if err != nil {
+		return err
+	}
+	return write(w, translated.(*rss.Feed).MarshalIndent)
+}
+
+// WriteAtom serializes the feed as Atom 1.0 to w using
+// the DefaultAtomReverseTranslator.
+func (f Feed) WriteAtom(w io.Writer) error {
+	translated, err := (&DefaultAtomReverseTranslator{}).Translate(&f)
+	if err != nil {
+		return err
+	}
+	return write(w, translated.(*atom.Feed).MarshalIndent)
+}
+
+// WriteJSON serializes the feed as JSON Feed 1.1 to w using
+// the DefaultJSONReverseTranslator.
+func (f Feed) WriteJSON(w io.Writer) error {
+	translated, err := (&DefaultJSONReverseTranslator{}).Translate(&f)
+	if err != nil {
+		return err
+	}
+	return write(w, translated.(*json.Feed).MarshalIndent)
+}
+
+func write(w io.Writer, marshal func(prefix, indent string) ([]byte, error)) error {
+	output, err := marshal("", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(output)
+	return err
+}